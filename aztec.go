@@ -0,0 +1,205 @@
+package reedSolomon
+
+import (
+	"fmt"
+)
+
+// Aztec codes (ISO/IEC 24778) don't use the GF(256) field set up by InitGaloisFields: the mode message (number of
+// layers and data codewords) is protected over GF(16), and the size of the data codewords depends on the number of layers.
+// All of them use a first consecutive root of 1.
+var (
+	aztecModeField = newGaloisField(4, 0x13, 1)    // x^4 + x + 1
+	aztecField6    = newGaloisField(6, 0x43, 1)    // x^6 + x + 1, 1 and 2 layers
+	aztecField8    = newGaloisField(8, 0x12D, 1)   // x^8 + x^5 + x^3 + x^2 + 1, 3 to 8 layers
+	aztecField10   = newGaloisField(10, 0x409, 1)  // x^10 + x^3 + 1, 9 to 22 layers
+	aztecField12   = newGaloisField(12, 0x1069, 1) // x^12 + x^6 + x^5 + x^3 + 1, 23 to 32 layers
+)
+
+// Layout of the mode message: the number of bits used for the layers and data codewords, and the number of ecc words
+const (
+	aztecCompactLayerBits = 2
+	aztecCompactDataBits  = 6
+	aztecCompactModeEcc   = 5
+	aztecFullLayerBits    = 5
+	aztecFullDataBits     = 11
+	aztecFullModeEcc      = 6
+)
+
+// AztecProfile describes the error correction of an Aztec symbol: the field of its data codewords
+// and how many codewords (data and ecc) fit in its layers.
+// It doesn't depend on InitGaloisFields, the fields of Aztec codes are built once by the package.
+type AztecProfile struct {
+	Compact bool
+	Layers  int
+	field   *galoisField
+}
+
+// NewAztecProfile creates the error correction profile of a compact (1 to 4 layers) or full (1 to 32 layers) Aztec symbol
+func NewAztecProfile(compact bool, layers int) (*AztecProfile, error) {
+	maxLayers := 32
+	if compact {
+		maxLayers = 4
+	}
+	if layers < 1 || layers > maxLayers {
+		return nil, fmt.Errorf("Invalid number of Aztec layers %d (must be between 1 and %d)", layers, maxLayers)
+	}
+
+	field := aztecField12
+	switch {
+	case layers <= 2:
+		field = aztecField6
+	case layers <= 8:
+		field = aztecField8
+	case layers <= 22:
+		field = aztecField10
+	}
+
+	return &AztecProfile{Compact: compact, Layers: layers, field: field}, nil
+}
+
+// WordSize returns the number of bits of the data codewords (6, 8, 10 or 12)
+func (a *AztecProfile) WordSize() int {
+	wordSize := 0
+	for n := a.field.order; n > 0; n >>= 1 {
+		wordSize++
+	}
+	return wordSize
+}
+
+// TotalCodewords returns the number of codewords (data and ecc) that fit in the layers of the symbol.
+// The bits left over are padding at the start of the data layers.
+func (a *AztecProfile) TotalCodewords() int {
+	core := 112
+	if a.Compact {
+		core = 88
+	}
+	return (core + 16*a.Layers) * a.Layers / a.WordSize()
+}
+
+// MaxDataCodewords returns the highest number of data codewords the mode message can describe (leaving at least one ecc codeword)
+func (a *AztecProfile) MaxDataCodewords() int {
+	max := 1 << aztecFullDataBits
+	if a.Compact {
+		max = 1 << aztecCompactDataBits
+	}
+	if max > a.TotalCodewords()-1 {
+		max = a.TotalCodewords() - 1
+	}
+	return max
+}
+
+// EccCodewords returns the number of ecc codewords protecting numDataCodewords data codewords: all the remaining codewords of the symbol
+func (a *AztecProfile) EccCodewords(numDataCodewords int) int {
+	return a.TotalCodewords() - numDataCodewords
+}
+
+// Encode appends the ecc codewords to the data codewords, filling the layers of the symbol
+func (a *AztecProfile) Encode(data []int) ([]int, error) {
+	if err := a.checkDataCodewords(len(data)); err != nil {
+		return []int{}, err
+	}
+	for i, w := range data {
+		if w < 0 || w > a.field.order {
+			return []int{}, fmt.Errorf("Codeword %d does not fit in %d bits (%d)", i, a.WordSize(), w)
+		}
+	}
+
+	return a.field.encode(data, a.EccCodewords(len(data)))
+}
+
+// Decode corrects the codewords read from the layers of the symbol and returns the numDataCodewords data codewords
+// (given by the mode message, see DecodeAztecModeMessage).
+// If the codewords can not be corrected the data codewords are returned as received along with the error.
+func (a *AztecProfile) Decode(codewords []int, numDataCodewords int, erasedIndices []int) ([]int, BlockStats, error) {
+	if err := a.checkDataCodewords(numDataCodewords); err != nil {
+		return []int{}, BlockStats{}, err
+	}
+	if len(codewords) != a.TotalCodewords() {
+		return []int{}, BlockStats{}, fmt.Errorf("Wrong number of codewords (%d when %d are expected)", len(codewords), a.TotalCodewords())
+	}
+	for i, w := range codewords {
+		if w < 0 || w > a.field.order {
+			return []int{}, BlockStats{}, fmt.Errorf("Codeword %d does not fit in %d bits (%d)", i, a.WordSize(), w)
+		}
+	}
+	for _, p := range erasedIndices {
+		if p < 0 || p >= len(codewords) {
+			return []int{}, BlockStats{}, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+	}
+
+	correctedMsg, stats := a.field.decodeBlock(codewords, a.EccCodewords(numDataCodewords), erasedIndices)
+	return correctedMsg, stats, stats.Err
+}
+
+func (a *AztecProfile) checkDataCodewords(numDataCodewords int) error {
+	if numDataCodewords < 1 || numDataCodewords > a.MaxDataCodewords() {
+		return fmt.Errorf("Invalid number of data codewords %d (must be between 1 and %d)", numDataCodewords, a.MaxDataCodewords())
+	}
+	return nil
+}
+
+// EncodeAztecModeMessage returns the 4-bit words of the mode message (7 for compact symbols, 10 for full symbols)
+// describing the number of layers and data codewords of the symbol, followed by their ecc words.
+func EncodeAztecModeMessage(compact bool, layers, numDataCodewords int) ([]int, error) {
+	a, err := NewAztecProfile(compact, layers)
+	if err != nil {
+		return []int{}, err
+	}
+	if err := a.checkDataCodewords(numDataCodewords); err != nil {
+		return []int{}, err
+	}
+
+	layerBits, dataBits, numberEccSymbols := aztecModeMessageLayout(compact)
+	bits := (layers-1)<<uint(dataBits) | (numDataCodewords - 1)
+
+	// Split the bits into 4-bit words, most significant first
+	words := make([]int, (layerBits+dataBits)/4)
+	for i := range words {
+		words[i] = bits >> uint(4*(len(words)-1-i)) & 0xF
+	}
+
+	return aztecModeField.encode(words, numberEccSymbols)
+}
+
+// DecodeAztecModeMessage corrects the 4-bit words of a mode message read from the core of the symbol
+// and returns the number of layers and data codewords it describes.
+func DecodeAztecModeMessage(compact bool, words []int, erasedIndices []int) (int, int, error) {
+	layerBits, dataBits, numberEccSymbols := aztecModeMessageLayout(compact)
+	numWords := (layerBits+dataBits)/4 + numberEccSymbols
+	if len(words) != numWords {
+		return 0, 0, fmt.Errorf("Wrong number of mode message words (%d when %d are expected)", len(words), numWords)
+	}
+	for i, w := range words {
+		if w < 0 || w > aztecModeField.order {
+			return 0, 0, fmt.Errorf("Mode message word %d does not fit in 4 bits (%d)", i, w)
+		}
+	}
+	for _, p := range erasedIndices {
+		if p < 0 || p >= len(words) {
+			return 0, 0, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+	}
+
+	correctedMsg, stats := aztecModeField.decodeBlock(words, numberEccSymbols, erasedIndices)
+	if stats.Err != nil {
+		return 0, 0, stats.Err
+	}
+
+	bits := 0
+	for _, w := range correctedMsg {
+		bits = bits<<4 | w
+	}
+	layers := bits>>uint(dataBits) + 1
+	numDataCodewords := bits&(1<<uint(dataBits)-1) + 1
+
+	return layers, numDataCodewords, nil
+}
+
+// Number of bits of the layers and data codewords fields, and number of ecc words of the mode message
+func aztecModeMessageLayout(compact bool) (int, int, int) {
+	if compact {
+		return aztecCompactLayerBits, aztecCompactDataBits, aztecCompactModeEcc
+	}
+	return aztecFullLayerBits, aztecFullDataBits, aztecFullModeEcc
+}
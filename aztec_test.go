@@ -0,0 +1,155 @@
+package reedSolomon
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAztecProfileTables(t *testing.T) {
+	t.Log("Testing Aztec code word sizes and capacities")
+
+	tests := []struct {
+		compact  bool
+		layers   int
+		wordSize int
+		total    int
+	}{
+		{true, 1, 6, 17},
+		{true, 4, 8, 76},
+		{false, 1, 6, 21},
+		{false, 3, 8, 60},
+		{false, 9, 10, 230},
+		{false, 22, 10, 1020},
+		{false, 23, 12, 920},
+		{false, 32, 12, 1664},
+	}
+
+	for _, test := range tests {
+		a, err := NewAztecProfile(test.compact, test.layers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.WordSize() != test.wordSize {
+			t.Errorf("%d layers were expected to use %d-bit codewords, but they used %d instead.", test.layers, test.wordSize, a.WordSize())
+		}
+		if a.TotalCodewords() != test.total {
+			t.Errorf("%d layers were expected to hold %d codewords, but they held %d instead.", test.layers, test.total, a.TotalCodewords())
+		}
+	}
+
+	a, _ := NewAztecProfile(true, 4)
+	if a.MaxDataCodewords() != 64 {
+		t.Errorf("Compact symbols were expected to hold at most 64 data codewords, but they held %d instead.", a.MaxDataCodewords())
+	}
+
+	if _, err := NewAztecProfile(true, 5); err == nil {
+		t.Error("Should have stated that the number of layers is invalid")
+	}
+}
+
+func TestAztecModeMessage(t *testing.T) {
+	t.Log("Testing Aztec mode message encoding")
+
+	// Compact symbol with 2 layers and 29 data codewords (from the zxing test suite)
+	expected := []int{5, 12, 1, 12, 2, 12, 13}
+	resp, err := EncodeAztecModeMessage(true, 2, 29)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+
+	// Full symbol, with 2 errors
+	resp, _ = EncodeAztecModeMessage(false, 17, 600)
+	if len(resp) != 10 {
+		t.Fatalf("Full mode message was expected to have 10 words, but it had %d instead.", len(resp))
+	}
+	resp[1] ^= 7
+	resp[8] ^= 3
+
+	layers, numDataCodewords, err := DecodeAztecModeMessage(false, resp, []int{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layers != 17 || numDataCodewords != 600 {
+		t.Errorf("Mode message was expected to describe 17 layers and 600 data codewords, but it described %d and %d instead.", layers, numDataCodewords)
+	}
+}
+
+func TestAztecProfileDecode(t *testing.T) {
+	t.Log("Testing Aztec data codewords correction")
+
+	for _, layers := range []int{2, 5, 12, 25} {
+		a, _ := NewAztecProfile(false, layers)
+
+		data := make([]int, a.TotalCodewords()*2/3)
+		for i := range data {
+			data[i] = (i*37 + 11) % (1 << uint(a.WordSize()))
+		}
+
+		codewords, err := a.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// As many errors as possible, half of the ecc codewords as erasures and the rest as errors
+		nsym := a.EccCodewords(len(data))
+		erasures := []int{}
+		for i := 0; i < nsym/2; i++ {
+			erasures = append(erasures, 2*i)
+			codewords[2*i] = 0
+		}
+		for i := 0; i < (nsym-nsym/2)/2; i++ {
+			codewords[2*i+1] ^= 1
+		}
+
+		resp, stats, err := a.Decode(codewords, len(data), erasures)
+		if err != nil {
+			t.Fatalf("Could not decode %d layers: %s", layers, err)
+		}
+		if stats.Erasures != nsym/2 {
+			t.Errorf("%d erasures were expected, but there were %d instead.", nsym/2, stats.Erasures)
+		}
+		for i, r := range resp {
+			if r != data[i] {
+				t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], r)
+			}
+		}
+	}
+}
+
+func TestAztecDecodeInvalidInput(t *testing.T) {
+	t.Log("Testing Aztec decoding with codewords and erasures out of range")
+
+	a, _ := NewAztecProfile(true, 1) // 6 bit codewords
+	codewords, _ := a.Encode([]int{1, 2, 3, 4, 5})
+
+	codewords[3] = 70
+	if _, _, err := a.Decode(codewords, 5, []int{}); err == nil || err.Error() != "Codeword 3 does not fit in 6 bits (70)" {
+		t.Error("Should have stated that codeword 3 does not fit in 6 bits")
+	}
+	codewords[3] = 4
+
+	for _, p := range []int{-1, len(codewords)} {
+		if _, _, err := a.Decode(codewords, 5, []int{p}); err == nil || err.Error() != fmt.Sprintf("Erasure position %d is out of range", p) {
+			t.Errorf("Should have stated that the erasure position %d is out of range", p)
+		}
+	}
+
+	words, _ := EncodeAztecModeMessage(true, 1, 5)
+	words[2] = 16
+	if _, _, err := DecodeAztecModeMessage(true, words, []int{}); err == nil || err.Error() != "Mode message word 2 does not fit in 4 bits (16)" {
+		t.Error("Should have stated that mode message word 2 does not fit in 4 bits")
+	}
+	words[2] = 0
+
+	for _, p := range []int{-1, len(words)} {
+		if _, _, err := DecodeAztecModeMessage(true, words, []int{p}); err == nil || err.Error() != fmt.Sprintf("Erasure position %d is out of range", p) {
+			t.Errorf("Should have stated that the erasure position %d is out of range", p)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package reedSolomon
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BatchResult is the outcome of decoding one codeword of a batch (see DecodeBatch)
+type BatchResult struct {
+	Msg []int // corrected message symbols
+	Ecc []int // corrected ecc symbols
+	Err error // nil if the codeword was decoded successfully
+}
+
+// DecodeBatch decodes independent codewords that all end with numberEccSymbols ecc symbols on a pool of GOMAXPROCS
+// goroutines. The results are in the order of codewords, each with its own error, and the codewords are not modified.
+// erasures is either nil or holds the erased indices of every codeword.
+// When ctx is cancelled the codewords that were not decoded yet get the error of ctx, which is then also returned.
+// The galois field look up tables must be initialized (see InitGaloisFields) before use.
+func DecodeBatch(ctx context.Context, codewords [][]int, numberEccSymbols int, erasures [][]int) ([]BatchResult, error) {
+	if erasures != nil && len(erasures) != len(codewords) {
+		return []BatchResult{}, fmt.Errorf("Wrong number of erasure lists (%d when %d are expected)", len(erasures), len(codewords))
+	}
+	if _, err := NewDecoder(numberEccSymbols); err != nil {
+		return []BatchResult{}, err
+	}
+
+	results := make([]BatchResult, len(codewords))
+
+	jobs := make(chan int, len(codewords))
+	for i := range codewords {
+		jobs <- i
+	}
+	close(jobs)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(codewords) {
+		workers = len(codewords)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			decoder, _ := NewDecoder(numberEccSymbols) // every worker has its own decoder, the arguments were checked above
+
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+
+				var erasedIndices []int
+				if erasures != nil {
+					erasedIndices = erasures[i]
+				}
+
+				// The decoder corrects in place, the results keep the copy
+				codeword := append([]int{}, codewords[i]...)
+				msg, ecc, err := decoder.Decode(codeword, erasedIndices)
+				results[i] = BatchResult{Msg: msg, Ecc: ecc, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The batch is only cancelled if codewords were skipped
+	if err := ctx.Err(); err != nil {
+		for _, r := range results {
+			if r.Err == err {
+				return results, err
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,94 @@
+package reedSolomon
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDecodeBatch(t *testing.T) {
+	t.Log("Testing decoding a batch of codewords")
+
+	codewords := make([][]int, 100)
+	erasures := make([][]int, len(codewords))
+	expected := make([][]int, len(codewords))
+
+	for i := range codewords {
+		msg := make([]int, 20+i%50)
+		for j := range msg {
+			msg[j] = (i*17 + j*5) & 0xFF
+		}
+		expected[i] = msg
+		codewords[i], _ = Encode(msg, 8)
+
+		// 2 errors and 4 erasures, too many errors for every 10th codeword
+		codewords[i][1] ^= 0x33
+		codewords[i][5] ^= 0x44
+		if i%10 == 0 {
+			codewords[i][7] ^= 0x55
+		}
+		erasures[i] = []int{9, 10, 11, 12}
+	}
+	received := codewords[3][1]
+
+	results, err := DecodeBatch(context.Background(), codewords, 8, erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(codewords) {
+		t.Fatalf("%d results were expected, but there were %d instead.", len(codewords), len(results))
+	}
+
+	for i, r := range results {
+		if i%10 == 0 {
+			if r.Err == nil {
+				t.Errorf("Codeword %d has too many errors, but it was decoded", i)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Fatalf("Codeword %d could not be decoded: %s", i, r.Err)
+		}
+		if len(r.Msg) != len(expected[i]) || len(r.Ecc) != 8 {
+			t.Fatalf("Codeword %d was expected to have %d message and 8 ecc symbols, but it had %d and %d instead.", i, len(expected[i]), len(r.Msg), len(r.Ecc))
+		}
+		for j, v := range r.Msg {
+			if v != expected[i][j] {
+				t.Errorf("Response %d at index %d was expected to be %d, but it was %d instead.", i, j, expected[i][j], v)
+			}
+		}
+	}
+
+	if codewords[3][1] != received {
+		t.Error("The codewords of the batch should not be modified")
+	}
+}
+
+func TestDecodeBatchCancelled(t *testing.T) {
+	t.Log("Testing cancelling a batch")
+
+	codeword, _ := Encode([]int{1, 2, 3, 4}, 4)
+	codewords := [][]int{codeword, codeword, codeword}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := DecodeBatch(ctx, codewords, 4, nil)
+	if err != context.Canceled {
+		t.Errorf("The error was expected to be %v, but it was %v instead.", context.Canceled, err)
+	}
+	for i, r := range results {
+		if r.Err != context.Canceled {
+			t.Errorf("The error of codeword %d was expected to be %v, but it was %v instead.", i, context.Canceled, r.Err)
+		}
+	}
+
+	// Decoding finished before the cancellation is not an error
+	results, err = DecodeBatch(context.Background(), codewords, 4, nil)
+	if err != nil || results[2].Err != nil {
+		t.Errorf("A batch of correct codewords was expected to decode, but it failed with %v", err)
+	}
+
+	if _, err := DecodeBatch(context.Background(), codewords, 4, [][]int{{}}); err == nil || err.Error() != "Wrong number of erasure lists (1 when 3 are expected)" {
+		t.Error("Should have stated that the number of erasure lists is wrong")
+	}
+}
@@ -0,0 +1,369 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Primitive polynomials of GF(2^m) for m = 4 to 15 (the ones used by the Linux BCH library for NAND flash)
+var bchPrimitivePolynomials = [...]int{0x13, 0x25, 0x43, 0x83, 0x11D, 0x211, 0x409, 0x805, 0x1053, 0x201B, 0x402B, 0x8003}
+
+const (
+	bchMinBits = 4
+	bchMaxBits = bchMinBits + len(bchPrimitivePolynomials) - 1
+)
+
+// BCHCode is a binary BCH code correcting up to t bit errors. The codewords are bits but the decoder works
+// over GF(2^m) like Reed-Solomon: the syndromes, the error locator (Berlekamp-Massey) and the error positions
+// (Chien search) are computed by the same functions, and since the symbols are bits all the error magnitudes are 1.
+// EncodeBytes and DecodeBytes work on packed bits (a NAND sector and its ecc bytes).
+type BCHCode struct {
+	field         *galoisField
+	t             int        // number of correctable bit errors
+	generator     []int      // binary generator polynomial, biggest degree first
+	generatorBits []uint64   // the generator without its x^ParityBits term, bit d is the coefficient of x^d
+	remainders    [][]uint64 // remainders[c] is the remainder of c(x) * x^ParityBits, to shift in a byte at a time
+}
+
+// NewBCHCode creates a BCH code over GF(2^m) (m between 4 and 15, 13 and 14 are typical for NAND flash pages)
+// correcting up to t bit errors. The codewords hold at most 2^m - 1 bits, shorter codewords are shortened codes.
+func NewBCHCode(m, t int) (*BCHCode, error) {
+	if m < bchMinBits || m > bchMaxBits {
+		return nil, fmt.Errorf("Invalid field size 2^%d (m must be between %d and %d)", m, bchMinBits, bchMaxBits)
+	}
+
+	field := newGaloisField(m, bchPrimitivePolynomials[m-bchMinBits], 1) // the roots are alpha^1 to alpha^2t
+	if t <= 0 || 2*t >= field.order {
+		return nil, fmt.Errorf("Invalid number of correctable errors %d", t)
+	}
+
+	b := &BCHCode{field: field, t: t, generator: bchGeneratorPolynomial(field, t)}
+	if b.ParityBits() >= field.order {
+		return nil, fmt.Errorf("Too many correctable errors %d (the generator polynomial fills the %d bits of a codeword)", t, field.order)
+	}
+
+	b.generatorBits = make([]uint64, (b.ParityBits()+63)/64)
+	for i, c := range b.generator[1:] {
+		if c != 0 {
+			d := b.ParityBits() - 1 - i
+			b.generatorBits[d/64] |= 1 << uint(d%64)
+		}
+	}
+
+	b.remainders = make([][]uint64, 256)
+	for c := range b.remainders {
+		b.remainders[c] = make([]uint64, len(b.generatorBits))
+		b.shiftBits(b.remainders[c], byte(c), 8)
+	}
+	return b, nil
+}
+
+// ParityBits returns the number of parity bits appended to the data (the degree of the generator polynomial, at most m*t)
+func (b *BCHCode) ParityBits() int {
+	return len(b.generator) - 1
+}
+
+// MaxDataBits returns the highest number of data bits of a codeword
+func (b *BCHCode) MaxDataBits() int {
+	return b.field.order - b.ParityBits()
+}
+
+// EccBytes returns the number of bytes holding the parity bits (the bits left in the last byte are 0)
+func (b *BCHCode) EccBytes() int {
+	return (b.ParityBits() + 7) / 8
+}
+
+// EncodeBytes computes the ecc bytes of data. The bits of data go from the most significant bit of its first byte, and
+// the parity bits are packed the same way.
+func (b *BCHCode) EncodeBytes(data []byte) ([]byte, error) {
+	if len(data) == 0 || 8*len(data) > b.MaxDataBits() {
+		return []byte{}, fmt.Errorf("Invalid data length (%d bytes when it must be between 1 and %d)", len(data), b.MaxDataBits()/8)
+	}
+	return b.packParity(b.remainder(data)), nil
+}
+
+// DecodeBytes corrects up to t bit errors of data and of its ecc bytes (see EncodeBytes) and returns the corrected data.
+// If the bits can not be corrected data is returned as received along with the error.
+func (b *BCHCode) DecodeBytes(data, ecc []byte) ([]byte, BlockStats, error) {
+	stats := BlockStats{}
+	if len(data) == 0 || 8*len(data) > b.MaxDataBits() {
+		return []byte{}, stats, fmt.Errorf("Invalid data length (%d bytes when it must be between 1 and %d)", len(data), b.MaxDataBits()/8)
+	}
+	if len(ecc) != b.EccBytes() {
+		return []byte{}, stats, fmt.Errorf("Wrong number of ecc bytes (%d when %d are expected)", len(ecc), b.EccBytes())
+	}
+
+	// The received codeword modulo the generator: the remainder of the received data plus the received parity bits.
+	// It has the same syndromes as the codeword, since the roots of the generator cancel the multiple of the generator.
+	received := b.unpackParity(ecc)
+	r := b.remainder(data)
+	clean := true
+	for i := range r {
+		r[i] ^= received[i]
+		if r[i] != 0 {
+			clean = false
+		}
+	}
+	if clean {
+		return data, stats, nil
+	}
+
+	nsym := 2 * b.t
+	synd := make([]int, nsym+1) // with the prepended 0 coefficient, like calculateSyndromes
+	for d := 0; d < b.ParityBits(); d++ {
+		if r[d/64]>>uint(d%64)&1 != 0 {
+			for i := 1; i <= nsym; i++ {
+				synd[i] ^= b.field.alphaPower(d * i)
+			}
+		}
+	}
+
+	errLoc, err := b.field.unknownErrorLocator(synd[1:], []int{}, nsym, 0)
+	if err != nil {
+		stats.Err = err
+		return data, stats, err
+	}
+	dataBits := 8 * len(data)
+	errPos, err := b.findBitErrors(errLoc, dataBits+b.ParityBits())
+	if err != nil {
+		stats.Err = err
+		return data, stats, err
+	}
+
+	// The magnitude of every error is 1: flip the bits, in the data or in the parity bits
+	corrected := make([]byte, len(data))
+	copy(corrected, data)
+	for _, p := range errPos {
+		if p < dataBits {
+			corrected[p/8] ^= 0x80 >> uint(p%8)
+		} else {
+			d := b.ParityBits() - 1 - (p - dataBits)
+			received[d/64] ^= 1 << uint(d%64)
+		}
+	}
+
+	for i, w := range b.remainder(corrected) {
+		if w != received[i] {
+			stats.Err = errors.New("Could not correct message")
+			return data, stats, stats.Err
+		}
+	}
+
+	stats.Corrected = len(errPos)
+	return corrected, stats, nil
+}
+
+// Remainder of data(x) * x^ParityBits divided by the generator, bit d of the result being the coefficient of x^d.
+// The bytes of data are shifted in from the biggest degree like the encoder of a CRC: the 8 bits of the remainder that
+// overflow, added to the byte, select the remainder to add.
+func (b *BCHCode) remainder(data []byte) []uint64 {
+	r := make([]uint64, len(b.generatorBits))
+	parityBits := b.ParityBits()
+	if parityBits < 8 {
+		for _, c := range data {
+			b.shiftBits(r, c, 8)
+		}
+		return r
+	}
+
+	top := len(r) - 1
+	shift := uint((parityBits - 8) % 64) // position of the 8 highest bits in the word that holds the lowest one
+	word := (parityBits - 8) / 64
+	for _, c := range data {
+		overflow := r[word] >> shift
+		if word < top && shift > 56 {
+			overflow |= r[word+1] << (64 - shift)
+		}
+		overflow = (overflow ^ uint64(c)) & 0xFF
+
+		for i := top; i > 0; i-- {
+			r[i] = r[i]<<8 | r[i-1]>>56
+		}
+		r[0] <<= 8
+		for i, w := range b.remainders[overflow] {
+			r[i] ^= w
+		}
+	}
+	r[top] &= b.topMask()
+	return r
+}
+
+// Shift the n lowest bits of c into the remainder r one bit at a time, from the most significant one
+func (b *BCHCode) shiftBits(r []uint64, c byte, n int) {
+	top := len(r) - 1
+	topBit := uint((b.ParityBits() - 1) % 64)
+
+	for k := n - 1; k >= 0; k-- {
+		feedback := (r[top]>>topBit ^ uint64(c)>>uint(k)) & 1
+
+		for i := top; i > 0; i-- {
+			r[i] = r[i]<<1 | r[i-1]>>63
+		}
+		r[0] <<= 1
+		r[top] &= b.topMask()
+
+		if feedback != 0 {
+			for i, g := range b.generatorBits {
+				r[i] ^= g
+			}
+		}
+	}
+}
+
+// The bits of the last word of a remainder that are below x^ParityBits
+func (b *BCHCode) topMask() uint64 {
+	return uint64(1)<<(uint((b.ParityBits()-1)%64)+1) - 1
+}
+
+// Chien search of the n bit positions of a codeword. Read from its lowest degree, the error locator (biggest degree first)
+// is the reciprocal polynomial whose roots are alpha^(n-1-p) for the error positions p. It is evaluated at alpha^i for
+// every position, each of its terms being kept in the log domain and multiplied by alpha^j from one position to the next.
+// A polynomial has no more roots than its degree, so the search stops when they are all found.
+func (b *BCHCode) findBitErrors(errLoc []int, n int) ([]int, error) {
+	gf := b.field
+	errs := len(errLoc) - 1
+
+	// Logs of the non-zero terms errLoc[j] * alpha^(j*i) and of their steps alpha^j
+	terms := []int{}
+	steps := []int{}
+	for j, c := range errLoc {
+		if c != 0 {
+			terms = append(terms, gf.logs[c])
+			steps = append(steps, j%gf.order)
+		}
+	}
+
+	errPos := []int{}
+	for i := 0; i < n && len(errPos) < errs; i++ {
+		y := 0
+		for k, l := range terms {
+			y ^= gf.exponents[l]
+			l += steps[k]
+			if l >= gf.order {
+				l -= gf.order
+			}
+			terms[k] = l
+		}
+		if y == 0 {
+			errPos = append(errPos, n-1-i)
+		}
+	}
+
+	if len(errPos) != errs {
+		return []int{}, errors.New("too many (or few) errors found by Chien Search for the errata locator polynomial")
+	}
+	return errPos, nil
+}
+
+// Pack the parity bits from the biggest degree, starting with the most significant bit of the first byte
+func (b *BCHCode) packParity(r []uint64) []byte {
+	ecc := make([]byte, b.EccBytes())
+	for j := 0; j < b.ParityBits(); j++ {
+		d := b.ParityBits() - 1 - j
+		if r[d/64]>>uint(d%64)&1 != 0 {
+			ecc[j/8] |= 0x80 >> uint(j%8)
+		}
+	}
+	return ecc
+}
+
+// Unpack the parity bits of packParity, the bits left in the last byte are ignored
+func (b *BCHCode) unpackParity(ecc []byte) []uint64 {
+	r := make([]uint64, len(b.generatorBits))
+	for j := 0; j < b.ParityBits(); j++ {
+		if ecc[j/8]&(0x80>>uint(j%8)) != 0 {
+			d := b.ParityBits() - 1 - j
+			r[d/64] |= 1 << uint(d%64)
+		}
+	}
+	return r
+}
+
+// encodeBits returns the data bits (one int per bit) followed by their parity bits, the unpacked form of EncodeBytes
+func (b *BCHCode) encodeBits(data []int) ([]int, error) {
+	if len(data) > b.MaxDataBits() {
+		return []int{}, fmt.Errorf("Message is too long (%d bits when max is %d)", len(data), b.MaxDataBits())
+	}
+	if err := checkBits(data); err != nil {
+		return []int{}, err
+	}
+
+	// The generator and the data only hold 0's and 1's, so the remainder does too
+	return b.field.encodeMessage(data, b.generator), nil
+}
+
+// decodeBits corrects up to t bit errors of a codeword (one int per bit) and returns its data bits, the unpacked form
+// of DecodeBytes. If the codeword can not be corrected the data bits are returned as received along with the error.
+func (b *BCHCode) decodeBits(codeword []int) ([]int, BlockStats, error) {
+	stats := BlockStats{}
+	nsym := 2 * b.t
+	dataLength := len(codeword) - b.ParityBits()
+
+	if dataLength <= 0 || len(codeword) > b.field.order {
+		return []int{}, stats, fmt.Errorf("Invalid codeword length (%d bits when it must be between %d and %d)", len(codeword), b.ParityBits()+1, b.field.order)
+	}
+	if err := checkBits(codeword); err != nil {
+		return []int{}, stats, err
+	}
+
+	synd := b.field.calculateSyndromes(codeword, nsym)
+	if isSyndromeClean(synd) {
+		return codeword[:dataLength], stats, nil
+	}
+
+	errLoc, err := b.field.unknownErrorLocator(synd[1:], []int{}, nsym, 0) // skip the prepended 0 coefficient of the syndrome
+	if err != nil {
+		stats.Err = err
+		return codeword[:dataLength], stats, err
+	}
+	errPos, err := b.field.findErrors(sliceIntReverse(errLoc), len(codeword))
+	if err != nil {
+		stats.Err = err
+		return codeword[:dataLength], stats, err
+	}
+
+	// The magnitude of every error is 1: flip the bits
+	corrected := make([]int, len(codeword))
+	copy(corrected, codeword)
+	for _, p := range errPos {
+		corrected[p] ^= 1
+	}
+
+	if !isSyndromeClean(b.field.calculateSyndromes(corrected, nsym)) {
+		stats.Err = errors.New("Could not correct message")
+		return codeword[:dataLength], stats, stats.Err
+	}
+
+	stats.Corrected = len(errPos)
+	return corrected[:dataLength], stats, nil
+}
+
+// Generator polynomial of a BCH code correcting t errors: the least common multiple of the minimal polynomials of
+// alpha^1 to alpha^2t. The minimal polynomial of alpha^i is the product of (x - alpha^c) for c in the cyclotomic coset
+// of i (i, 2i, 4i, ... mod 2^m - 1), which only has binary coefficients. The even powers belong to the coset of i/2,
+// so only the cosets of the odd powers are used.
+func bchGeneratorPolynomial(gf *galoisField, t int) []int {
+	generator := []int{1}
+	used := make([]bool, gf.order)
+
+	for i := 1; i < 2*t; i += 2 {
+		if used[i] {
+			continue
+		}
+		for c := i; !used[c]; c = (2 * c) % gf.order {
+			used[c] = true
+			generator = gf.polynomialMultiplication(generator, []int{1, gf.alphaPower(c)})
+		}
+	}
+	return generator
+}
+
+// Check that the symbols are bits
+func checkBits(bits []int) error {
+	for i, bit := range bits {
+		if bit != 0 && bit != 1 {
+			return fmt.Errorf("Symbol %d is not a bit (%d)", i, bit)
+		}
+	}
+	return nil
+}
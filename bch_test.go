@@ -0,0 +1,241 @@
+package reedSolomon
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestBCHGeneratorPolynomial(t *testing.T) {
+	t.Log("Testing BCH generator polynomials")
+
+	tests := []struct {
+		m, t     int
+		expected []int
+	}{
+		{4, 2, []int{1, 1, 1, 0, 1, 0, 0, 0, 1}},       // BCH(15,7): x^8 + x^7 + x^6 + x^4 + 1
+		{4, 3, []int{1, 0, 1, 0, 0, 1, 1, 0, 1, 1, 1}}, // BCH(15,5): x^10 + x^8 + x^5 + x^4 + x^2 + x + 1
+		{5, 2, []int{1, 1, 1, 0, 1, 1, 0, 1, 0, 0, 1}}, // BCH(31,21): x^10 + x^9 + x^8 + x^6 + x^5 + x^3 + 1
+	}
+
+	for _, test := range tests {
+		b, err := NewBCHCode(test.m, test.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b.generator) != len(test.expected) {
+			t.Fatalf("The generator was expected to have degree %d, but it had degree %d instead.", len(test.expected)-1, len(b.generator)-1)
+		}
+		for i, r := range b.generator {
+			if r != test.expected[i] {
+				t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, test.expected[i], r)
+			}
+		}
+	}
+
+	if _, err := NewBCHCode(16, 4); err == nil {
+		t.Error("Should have stated that the field size is invalid")
+	}
+}
+
+func TestBCHCodeNAND(t *testing.T) {
+	t.Log("Testing BCH codes of NAND flash pages")
+
+	r := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		m, t     int
+		dataBits int
+	}{
+		{13, 8, 512 * 8},   // 512-byte sector
+		{14, 24, 1024 * 8}, // 1024-byte sector
+	}
+
+	for _, test := range tests {
+		b, err := NewBCHCode(test.m, test.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.ParityBits() != test.m*test.t {
+			t.Errorf("%d parity bits were expected, but there were %d instead.", test.m*test.t, b.ParityBits())
+		}
+
+		data := make([]int, test.dataBits)
+		for i := range data {
+			data[i] = r.Intn(2)
+		}
+		codeword, err := b.encodeBits(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range r.Perm(len(codeword))[:test.t] {
+			codeword[p] ^= 1
+		}
+
+		resp, stats, err := b.decodeBits(codeword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Corrected != test.t {
+			t.Errorf("%d corrections were expected, but there were %d instead.", test.t, stats.Corrected)
+		}
+		for i, bit := range resp {
+			if bit != data[i] {
+				t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], bit)
+			}
+		}
+	}
+}
+
+func TestBCHCodeTooManyErrors(t *testing.T) {
+	t.Log("Testing a BCH code with too many errors")
+
+	b, _ := NewBCHCode(13, 4)
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i % 3 % 2
+	}
+	codeword, _ := b.encodeBits(data)
+	for i := 0; i < 6; i++ {
+		codeword[100*i+7] ^= 1
+	}
+
+	if _, _, err := b.decodeBits(codeword); err == nil {
+		t.Error("Should have stated that the codeword could not be corrected")
+	}
+
+	if _, _, err := b.decodeBits([]int{2, 0, 1}); err == nil {
+		t.Error("Should have stated that the codeword is invalid")
+	}
+}
+
+// Unpack bytes into one int per bit, the most significant bit first
+func bchTestBits(data []byte, n int) []int {
+	bits := make([]int, n)
+	for i := range bits {
+		bits[i] = int(data[i/8] >> uint(7-i%8) & 1)
+	}
+	return bits
+}
+
+func TestBCHCodeBytes(t *testing.T) {
+	t.Log("Testing BCH codes of packed NAND flash sectors")
+
+	r := rand.New(rand.NewSource(2))
+
+	tests := []struct {
+		m, t     int
+		sector   int
+		eccBytes int
+	}{
+		{13, 8, 512, 13},   // 104 parity bits
+		{14, 24, 1024, 42}, // 336 parity bits
+		{15, 40, 2048, 75}, // 600 parity bits, in 10 words
+	}
+
+	for _, test := range tests {
+		b, err := NewBCHCode(test.m, test.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.EccBytes() != test.eccBytes {
+			t.Errorf("%d ecc bytes were expected, but there were %d instead.", test.eccBytes, b.EccBytes())
+		}
+
+		data := make([]byte, test.sector)
+		r.Read(data)
+		ecc, err := b.EncodeBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The same parity bits as the unpacked encoder
+		codeword, _ := b.encodeBits(bchTestBits(data, 8*len(data)))
+		for i, bit := range bchTestBits(ecc, b.ParityBits()) {
+			if bit != codeword[8*len(data)+i] {
+				t.Fatalf("Parity bit %d was expected to be %d, but it was %d instead.", i, codeword[8*len(data)+i], bit)
+			}
+		}
+
+		// t bit errors, spread over the data and the ecc bytes
+		received := append([]byte{}, data...)
+		receivedEcc := append([]byte{}, ecc...)
+		for _, p := range r.Perm(8*len(data) + b.ParityBits())[:test.t] {
+			if p < 8*len(data) {
+				received[p/8] ^= 0x80 >> uint(p%8)
+			} else {
+				p -= 8 * len(data)
+				receivedEcc[p/8] ^= 0x80 >> uint(p%8)
+			}
+		}
+
+		resp, stats, err := b.DecodeBytes(received, receivedEcc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Corrected != test.t {
+			t.Errorf("%d corrections were expected, but there were %d instead.", test.t, stats.Corrected)
+		}
+		for i, c := range resp {
+			if c != data[i] {
+				t.Fatalf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], c)
+			}
+		}
+
+		// A correct sector
+		if _, stats, err := b.DecodeBytes(data, ecc); err != nil || stats.Corrected != 0 {
+			t.Errorf("A correct sector was expected to have no corrections, but it had %d (%v)", stats.Corrected, err)
+		}
+	}
+}
+
+func TestBCHCodeBytesTooManyErrors(t *testing.T) {
+	t.Log("Testing packed BCH sectors with too many errors")
+
+	b, _ := NewBCHCode(13, 4)
+	data := make([]byte, 512)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	ecc, _ := b.EncodeBytes(data)
+
+	received := append([]byte{}, data...)
+	for i := 0; i < 6; i++ {
+		received[80*i+3] ^= 0x10
+	}
+	resp, _, err := b.DecodeBytes(received, ecc)
+	if err == nil {
+		t.Error("Should have stated that the sector could not be corrected")
+	}
+	if !bytes.Equal(resp, received) {
+		t.Error("The data was expected to be returned as received")
+	}
+
+	if _, _, err := b.DecodeBytes(data, ecc[1:]); err == nil || err.Error() != "Wrong number of ecc bytes (6 when 7 are expected)" {
+		t.Error("Should have stated that the number of ecc bytes is wrong")
+	}
+	if _, err := b.EncodeBytes(make([]byte, 1024)); err == nil || err.Error() != "Invalid data length (1024 bytes when it must be between 1 and 1017)" {
+		t.Error("Should have stated that the data is too long")
+	}
+}
+
+// A 512-byte sector with 8 bit errors
+func BenchmarkBCHDecodeBytes(b *testing.B) {
+	bch, _ := NewBCHCode(13, 8)
+	data := make([]byte, 512)
+	for i := range data {
+		data[i] = byte(i * 29)
+	}
+	ecc, _ := bch.EncodeBytes(data)
+	for i := 0; i < 8; i++ {
+		data[60*i] ^= 0x04
+	}
+
+	b.SetBytes(512)
+	for i := 0; i < b.N; i++ {
+		if _, _, err := bch.DecodeBytes(data, ecc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
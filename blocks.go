@@ -0,0 +1,131 @@
+package reedSolomon
+
+import (
+	"fmt"
+)
+
+// BlockCodec protects messages of any length by splitting them into RS(255, k) codewords,
+// k = 255 - numberEccSymbols. The last block is shortened (it only holds the remaining data symbols).
+// The galois field look up tables must be initialized (see InitGaloisFields) before use.
+type BlockCodec struct {
+	dataSymbols      int // k: number of data symbols in a full block
+	numberEccSymbols int // number of ecc symbols appended to every block
+	generator        []int
+}
+
+// BlockStats reports the outcome of decoding a single block
+type BlockStats struct {
+	Erasures  int   // number of erasures provided for this block
+	Corrected int   // number of symbols (errors and erasures) that had to be corrected
+	Err       error // nil if the block was decoded successfully
+}
+
+// NewBlockCodec creates a block codec appending numberEccSymbols ecc symbols to every block of 255-numberEccSymbols data symbols
+func NewBlockCodec(numberEccSymbols int) (*BlockCodec, error) {
+	if numberEccSymbols <= 0 || numberEccSymbols >= 255 {
+		return nil, fmt.Errorf("Invalid number of ecc symbols (%d when it must be between 1 and 254)", numberEccSymbols)
+	}
+
+	return &BlockCodec{
+		dataSymbols:      255 - numberEccSymbols,
+		numberEccSymbols: numberEccSymbols,
+		generator:        generatorPolynomial(numberEccSymbols),
+	}, nil
+}
+
+// EncodedLength returns the number of symbols msgLength data symbols take once encoded
+func (c *BlockCodec) EncodedLength(msgLength int) int {
+	blocks := (msgLength + c.dataSymbols - 1) / c.dataSymbols
+	return msgLength + blocks*c.numberEccSymbols
+}
+
+// Encode splits msg into blocks and returns the concatenated codewords (each block followed by its ecc symbols)
+func (c *BlockCodec) Encode(msg []int) []int {
+	msgOut := make([]int, 0, c.EncodedLength(len(msg)))
+
+	for start := 0; start < len(msg); start += c.dataSymbols {
+		end := start + c.dataSymbols
+		if end > len(msg) {
+			end = len(msg) // shortened last block
+		}
+		msgOut = append(msgOut, encodeMessage(msg[start:end], c.generator)...)
+	}
+
+	return msgOut
+}
+
+// Decode corrects every block of an encoded message and returns the data symbols along with the statistics of each block.
+// erasedIndices are positions in the encoded message, they are mapped to the block they belong to.
+// Blocks that can not be corrected are returned as received and the first failure is returned as the error.
+func (c *BlockCodec) Decode(msg []int, erasedIndices []int) ([]int, []BlockStats, error) {
+	blockLength := c.dataSymbols + c.numberEccSymbols
+	blocks := (len(msg) + blockLength - 1) / blockLength
+	if last := len(msg) % blockLength; last != 0 && last <= c.numberEccSymbols {
+		return []int{}, []BlockStats{}, fmt.Errorf("Invalid encoded length (the last block has %d symbols when at least %d are required)", last, c.numberEccSymbols+1)
+	}
+
+	// Map the erasures to the block they belong to
+	blockErasures := make([][]int, blocks)
+	for _, p := range erasedIndices {
+		if p < 0 || p >= len(msg) {
+			return []int{}, []BlockStats{}, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+		blockErasures[p/blockLength] = append(blockErasures[p/blockLength], p%blockLength)
+	}
+
+	msgOut := make([]int, 0, len(msg)-blocks*c.numberEccSymbols)
+	stats := make([]BlockStats, blocks)
+	var err error
+
+	for b := 0; b < blocks; b++ {
+		start := b * blockLength
+		end := start + blockLength
+		if end > len(msg) {
+			end = len(msg)
+		}
+
+		var correctedMsg []int
+		correctedMsg, stats[b] = decodeBlock(msg[start:end], c.numberEccSymbols, blockErasures[b])
+		if stats[b].Err != nil && err == nil {
+			err = fmt.Errorf("Could not decode block %d: %s", b, stats[b].Err)
+		}
+		msgOut = append(msgOut, correctedMsg...)
+	}
+
+	return msgOut, stats, err
+}
+
+// Decode a single codeword without modifying it and gather its statistics.
+// If the codeword can not be corrected its data symbols are returned as received.
+func (gf *galoisField) decodeBlock(msg []int, numberEccSymbols int, erasedIndices []int) ([]int, BlockStats) {
+	stats := BlockStats{Erasures: len(erasedIndices)}
+
+	block := make([]int, len(msg)) // Decode modifies the message it is given
+	copy(block, msg)
+
+	correctedMsg, correctedEcc, err := gf.decode(block, numberEccSymbols, erasedIndices)
+	eccStart := len(msg) - numberEccSymbols
+	if err != nil {
+		stats.Err = err
+		return msg[:eccStart], stats // keep the data symbols as received
+	}
+
+	stats.Corrected = countCorrections(msg[:eccStart], correctedMsg) + countCorrections(msg[eccStart:], correctedEcc)
+	return correctedMsg, stats
+}
+
+// decodeBlock on the GF(256) field initialized by InitGaloisFields
+func decodeBlock(msg []int, numberEccSymbols int, erasedIndices []int) ([]int, BlockStats) {
+	return defaultField().decodeBlock(msg, numberEccSymbols, erasedIndices)
+}
+
+// Count the number of symbols that differ between the received and corrected codewords
+func countCorrections(received, corrected []int) int {
+	count := 0
+	for i := range received {
+		if received[i] != corrected[i] {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,109 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+func makeTestPayload(length int) []int {
+	msg := make([]int, length)
+	for i := range msg {
+		msg[i] = (i * 7) % 256
+	}
+	return msg
+}
+
+func TestNewBlockCodec(t *testing.T) {
+	t.Log("Testing creating block codecs")
+
+	if _, err := NewBlockCodec(0); err == nil {
+		t.Error("Should fail without ecc symbols")
+	}
+	if _, err := NewBlockCodec(255); err == nil {
+		t.Error("Should fail without data symbols")
+	}
+}
+
+func TestBlockCodecEncode(t *testing.T) {
+	t.Log("Testing encoding a long message in blocks")
+
+	c, _ := NewBlockCodec(32) // RS(255, 223)
+	msg := makeTestPayload(500)
+
+	encoded := c.Encode(msg)
+
+	// 2 full blocks of 223 data symbols and a shortened block of 54 data symbols
+	if len(encoded) != 500+3*32 || c.EncodedLength(500) != len(encoded) {
+		t.Fatalf("Expected encoded length to be %d, but it was %d instead.", 500+3*32, len(encoded))
+	}
+
+	for _, block := range [][]int{encoded[:255], encoded[255:510], encoded[510:]} {
+		if !isSyndromeClean(calculateSyndromes(block, 32)) {
+			t.Error("Block is not a valid codeword")
+		}
+	}
+}
+
+func TestBlockCodecDecode(t *testing.T) {
+	t.Log("Testing decoding a long message with errors in several blocks")
+
+	c, _ := NewBlockCodec(16)
+	msg := makeTestPayload(600)
+	encoded := c.Encode(msg)
+
+	// Block 0: 3 errors, block 1: no errors, block 2 (shortened): 2 errors and 2 erasures
+	encoded[0] ^= 1
+	encoded[100] ^= 2
+	encoded[254] ^= 3
+	encoded[520] ^= 4
+	encoded[600] ^= 5
+	encoded[530] = 0
+	encoded[531] = 0
+	erasures := []int{530, 531}
+
+	decoded, stats, err := c.Decode(encoded, erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, r := range decoded {
+		if r != msg[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, msg[i], r)
+		}
+	}
+
+	expected := []BlockStats{{Erasures: 0, Corrected: 3}, {Erasures: 0, Corrected: 0}, {Erasures: 2, Corrected: 4}}
+	for i, s := range stats {
+		if s != expected[i] {
+			t.Errorf("Stats of block %d were expected to be %+v, but they were %+v instead.", i, expected[i], s)
+		}
+	}
+}
+
+func TestBlockCodecDecodeFailure(t *testing.T) {
+	t.Log("Testing decoding a long message with an uncorrectable block")
+
+	c, _ := NewBlockCodec(4)
+	msg := makeTestPayload(300)
+	encoded := c.Encode(msg)
+
+	// 3 errors in the second block (max is 2)
+	encoded[260] ^= 1
+	encoded[270] ^= 1
+	encoded[280] ^= 1
+
+	decoded, stats, err := c.Decode(encoded, []int{})
+	if err == nil {
+		t.Fatal("Should have failed to decode block 1")
+	}
+	if stats[0].Err != nil || stats[1].Err == nil {
+		t.Error("Only block 1 should have failed")
+	}
+	if len(decoded) != len(msg) {
+		t.Errorf("Expected decoded length to be %d, but it was %d instead.", len(msg), len(decoded))
+	}
+
+	// Last block shorter than the ecc symbols
+	if _, _, err := c.Decode(make([]int, 258), []int{}); err == nil {
+		t.Error("Should have stated that the encoded length is invalid")
+	}
+}
@@ -0,0 +1,137 @@
+package reedSolomon
+
+import (
+	"fmt"
+)
+
+// CCSDS telemetry channel coding (CCSDS 131.0-B) uses RS(255,223) over GF(256) with the field polynomial
+// x^8 + x^7 + x^2 + x + 1 (0x187). The roots of its generator polynomial are beta^112 to beta^143 with beta = alpha^11,
+// and the symbols are transmitted in the Berlekamp dual basis instead of the conventional basis.
+var ccsdsField = newCCSDSField()
+
+const (
+	ccsdsCodewordLength = 255
+	ccsdsEccSymbols     = 32 // E = 16 symbol errors per codeword
+	ccsdsDataSymbols    = ccsdsCodewordLength - ccsdsEccSymbols
+	ccsdsMaxDepth       = 8
+)
+
+// Rows of the matrix converting the conventional basis to the dual basis (CCSDS 131.0-B Annex F)
+var ccsdsTal = [8]int{0x8d, 0xef, 0xec, 0x86, 0xfa, 0x99, 0xaf, 0x7b}
+
+// Conversion tables between the conventional and dual basis representations of the symbols
+var ccsdsToDual, ccsdsToConventional = ccsdsBasisTables()
+
+func newCCSDSField() *galoisField {
+	gf := newGaloisField(8, 0x187, 112)
+	gf.useGenerator(11)
+	return gf
+}
+
+func ccsdsBasisTables() ([256]int, [256]int) {
+	var toDual, toConventional [256]int
+
+	for i := 0; i < 256; i++ {
+		for k := 0; k < 8; k++ { // each bit of the conventional representation adds a row of the matrix
+			if i&(1<<uint(k)) != 0 {
+				toDual[i] ^= ccsdsTal[7-k]
+			}
+		}
+		toConventional[toDual[i]] = i
+	}
+
+	return toDual, toConventional
+}
+
+// CCSDSDualBasis converts symbols from the conventional basis to the Berlekamp dual basis used on the channel
+func CCSDSDualBasis(symbols []int) ([]int, error) {
+	return ccsdsConvert(symbols, &ccsdsToDual)
+}
+
+// CCSDSConventionalBasis converts symbols from the Berlekamp dual basis used on the channel to the conventional basis
+func CCSDSConventionalBasis(symbols []int) ([]int, error) {
+	return ccsdsConvert(symbols, &ccsdsToConventional)
+}
+
+// Convert symbols with one of the basis tables, the symbols must fit in 8 bits
+func ccsdsConvert(symbols []int, table *[256]int) ([]int, error) {
+	out := make([]int, len(symbols))
+	for i, s := range symbols {
+		if s < 0 || s > 0xFF {
+			return []int{}, fmt.Errorf("Symbol %d does not fit in 8 bits (%d)", i, s)
+		}
+		out[i] = table[s]
+	}
+	return out, nil
+}
+
+// CCSDSProfile describes a CCSDS Reed-Solomon codeblock: depth interleaved RS(255,223) codewords, each shortened by
+// virtualFill symbols (the virtual fill is zero data that is never transmitted).
+// The symbols of the codeblock are in the dual basis. It doesn't depend on InitGaloisFields.
+type CCSDSProfile struct {
+	Depth       int
+	VirtualFill int
+	layout      *interleavedLayout
+}
+
+// NewCCSDSProfile creates a codeblock profile for an interleaving depth (1 to 8) and a virtual fill (0 to 222 symbols per codeword)
+func NewCCSDSProfile(depth, virtualFill int) (*CCSDSProfile, error) {
+	if depth < 1 || depth > ccsdsMaxDepth {
+		return nil, fmt.Errorf("Invalid interleaving depth %d (must be between 1 and %d)", depth, ccsdsMaxDepth)
+	}
+	if virtualFill < 0 || virtualFill >= ccsdsDataSymbols {
+		return nil, fmt.Errorf("Invalid virtual fill %d (must be between 0 and %d)", virtualFill, ccsdsDataSymbols-1)
+	}
+
+	// Symbol i of codeword j is at i*depth + j, which is the column layout of blocks of the same length
+	dataLengths := make([]int, depth)
+	for j := range dataLengths {
+		dataLengths[j] = ccsdsDataSymbols - virtualFill
+	}
+	l := columnInterleavedLayout(dataLengths, ccsdsEccSymbols)
+	l.interleavedData = true
+	l.field = ccsdsField
+
+	return &CCSDSProfile{Depth: depth, VirtualFill: virtualFill, layout: l}, nil
+}
+
+// DataLength returns the number of data symbols of a codeblock
+func (c *CCSDSProfile) DataLength() int {
+	return c.layout.dataLength()
+}
+
+// CodeblockLength returns the number of symbols of a codeblock (data and check symbols)
+func (c *CCSDSProfile) CodeblockLength() int {
+	return c.layout.length()
+}
+
+// Encode computes the check symbols of the interleaved codewords and returns the codeblock.
+// data holds DataLength symbols in the dual basis, in the order they are transmitted.
+func (c *CCSDSProfile) Encode(data []int) ([]int, error) {
+	conventional, err := CCSDSConventionalBasis(data)
+	if err != nil {
+		return []int{}, err
+	}
+	codeblock, err := c.layout.encode(conventional)
+	if err != nil {
+		return []int{}, err
+	}
+	return CCSDSDualBasis(codeblock)
+}
+
+// Decode corrects the interleaved codewords of a codeblock (in the dual basis) and returns the data symbols in the dual basis.
+// erasedIndices are positions in the codeblock. Codewords that can not be corrected are returned as received and
+// the first failure is returned as the error.
+func (c *CCSDSProfile) Decode(codeblock []int, erasedIndices []int) ([]int, []BlockStats, error) {
+	conventional, err := CCSDSConventionalBasis(codeblock)
+	if err != nil {
+		return []int{}, []BlockStats{}, err
+	}
+
+	data, stats, err := c.layout.decode(conventional, erasedIndices)
+	if len(data) == 0 {
+		return data, stats, err
+	}
+	dual, _ := CCSDSDualBasis(data) // the corrected symbols are in the field
+	return dual, stats, err
+}
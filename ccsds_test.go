@@ -0,0 +1,240 @@
+package reedSolomon
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestCCSDSGeneratorPolynomial(t *testing.T) {
+	t.Log("Testing the CCSDS generator polynomial")
+
+	// Coefficients of g(x) as powers of alpha (CCSDS 131.0-B, also used by the reference implementation of P. Karn)
+	expected := []int{0, 249, 59, 66, 4, 43, 126, 251, 97, 30, 3, 213, 50, 66, 170, 5, 24, 5, 170, 66, 50, 213, 3, 30, 97, 251, 126, 43, 4, 66, 59, 249, 0}
+
+	conventional := newGaloisField(8, 0x187, 0) // logs in powers of alpha instead of beta
+	for i, c := range ccsdsField.generatorPolynomial(ccsdsEccSymbols) {
+		if conventional.logs[c] != expected[i] {
+			t.Errorf("Response at index %d was expected to be alpha^%d, but it was alpha^%d instead.", i, expected[i], conventional.logs[c])
+		}
+	}
+}
+
+func TestCCSDSDualBasis(t *testing.T) {
+	t.Log("Testing the conversion between the conventional and dual basis")
+
+	// Dual basis representation of the conventional symbols 0 to 15 (CCSDS 131.0-B Annex F)
+	expected := []int{0x00, 0x7b, 0xaf, 0xd4, 0x99, 0xe2, 0x36, 0x4d, 0xfa, 0x81, 0x55, 0x2e, 0x63, 0x18, 0xcc, 0xb7}
+	symbols := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+	resp, err := CCSDSDualBasis(symbols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+
+	all := make([]int, 256)
+	for i := range all {
+		all[i] = i
+	}
+	dual, _ := CCSDSDualBasis(all)
+	conventional, _ := CCSDSConventionalBasis(dual)
+	for i, r := range conventional {
+		if r != i {
+			t.Errorf("Symbol %d was converted back to %d.", i, r)
+		}
+	}
+
+	for _, s := range []int{-1, 256, 300} {
+		if _, err := CCSDSDualBasis([]int{0, s}); err == nil || err.Error() != fmt.Sprintf("Symbol 1 does not fit in 8 bits (%d)", s) {
+			t.Errorf("Should have stated that the symbol %d does not fit in 8 bits", s)
+		}
+		if _, err := CCSDSConventionalBasis([]int{s}); err == nil || err.Error() != fmt.Sprintf("Symbol 0 does not fit in 8 bits (%d)", s) {
+			t.Errorf("Should have stated that the symbol %d does not fit in 8 bits", s)
+		}
+	}
+}
+
+func TestCCSDSKnownCodeblocks(t *testing.T) {
+	t.Log("Testing the check symbols of fixed CCSDS codewords")
+
+	// The codeword whose data is 0 except a last symbol of 1 (conventional basis) is the generator polynomial itself, and
+	// alpha^37 times it when the last symbol is alpha^37. The check symbols were computed outside of the package from the
+	// coefficients of g(x) published as powers of alpha (CCSDS 131.0-B, CCSDS_poly of P. Karn) and the matrix of Annex F.
+	tests := []struct {
+		last     int // last data symbol, in the dual basis
+		expected []int
+	}{
+		{0x00, make([]int, 32)},
+		{0x7b, []int{
+			0x47, 0x32, 0x5f, 0x86, 0x4a, 0x18, 0xa0, 0x78, 0x83, 0xfa, 0xb9, 0x5c, 0x5f, 0x4f, 0xec, 0xfe,
+			0xec, 0x4f, 0x5f, 0x5c, 0xb9, 0xfa, 0x83, 0x78, 0xa0, 0x18, 0x4a, 0x86, 0x5f, 0x32, 0x47, 0x7b}},
+		{0xad, []int{
+			0x7a, 0xdc, 0x6a, 0xfb, 0x8c, 0x10, 0x3f, 0x50, 0x02, 0xac, 0xd1, 0x97, 0x6a, 0x75, 0xb7, 0xab,
+			0xb7, 0x75, 0x6a, 0x97, 0xd1, 0xac, 0x02, 0x50, 0x3f, 0x10, 0x8c, 0xfb, 0x6a, 0xdc, 0x7a, 0xad}},
+	}
+
+	c, _ := NewCCSDSProfile(1, 0)
+	for _, test := range tests {
+		data := make([]int, 223)
+		data[222] = test.last
+
+		codeblock, err := c.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range codeblock[223:] {
+			if v != test.expected[i] {
+				t.Errorf("Check symbol %d for a last data symbol of %d was expected to be %d, but it was %d instead.", i, test.last, test.expected[i], v)
+			}
+		}
+	}
+}
+
+func TestCCSDSInvalidSymbols(t *testing.T) {
+	t.Log("Testing CCSDS codeblocks with symbols that do not fit in 8 bits")
+
+	c, _ := NewCCSDSProfile(2, 0)
+	data := make([]int, c.DataLength())
+	data[10] = 300
+	if _, err := c.Encode(data); err == nil || err.Error() != "Symbol 10 does not fit in 8 bits (300)" {
+		t.Error("Should have stated that symbol 10 does not fit in 8 bits")
+	}
+
+	data[10] = 0
+	codeblock, _ := c.Encode(data)
+	codeblock[400] = 300
+	if _, _, err := c.Decode(codeblock, []int{}); err == nil || err.Error() != "Symbol 400 does not fit in 8 bits (300)" {
+		t.Error("Should have stated that symbol 400 does not fit in 8 bits")
+	}
+}
+
+// Encoder of the reference implementation of P. Karn (encode_rs_ccsds): a shift register fed with the conventional
+// basis symbols, whose taps are the coefficients of the generator polynomial as powers of alpha (CCSDS 131.0-B).
+// pad is the number of symbols of virtual fill. It does not use the generator polynomial or the encoder of the package.
+func karnEncodeCCSDS(data []int, pad int) []int {
+	genpoly := []int{0, 249, 59, 66, 4, 43, 126, 251, 97, 30, 3, 213, 50, 66, 170, 5, 24, 5, 170, 66, 50, 213, 3, 30, 97, 251, 126, 43, 4, 66, 59, 249, 0}
+	conventional := newGaloisField(8, 0x187, 0) // alphaTo and indexOf of the reference implementation
+	const nn, nroots, a0 = 255, 32, 255
+
+	parity := make([]int, nroots)
+	for i := 0; i < nn-nroots-pad; i++ {
+		feedback := a0
+		if x := ccsdsToConventional[data[i]] ^ parity[0]; x != 0 {
+			feedback = conventional.logs[x]
+		}
+		if feedback != a0 {
+			for j := 1; j < nroots; j++ {
+				parity[j] ^= conventional.exponents[(feedback+genpoly[nroots-j])%nn]
+			}
+		}
+		copy(parity, parity[1:])
+		parity[nroots-1] = 0
+		if feedback != a0 {
+			parity[nroots-1] = conventional.exponents[(feedback+genpoly[0])%nn]
+		}
+	}
+
+	for i, p := range parity {
+		parity[i] = ccsdsToDual[p]
+	}
+	return parity
+}
+
+func TestCCSDSReferenceEncoder(t *testing.T) {
+	t.Log("Testing the check symbols of CCSDS codewords against the reference encoder")
+
+	r := rand.New(rand.NewSource(131))
+	for _, virtualFill := range []int{0, 33, 222} {
+		c, _ := NewCCSDSProfile(1, virtualFill)
+
+		for trial := 0; trial < 20; trial++ {
+			data := make([]int, c.DataLength())
+			for i := range data {
+				data[i] = r.Intn(256)
+			}
+			if trial == 0 {
+				for i := range data {
+					data[i] = 0xFF // the data of a codeword is often the idle pattern
+				}
+			}
+
+			codeblock, err := c.Encode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := karnEncodeCCSDS(data, virtualFill)
+			for i, v := range codeblock[len(data):] {
+				if v != expected[i] {
+					t.Fatalf("Check symbol %d with a virtual fill of %d was expected to be %d, but it was %d instead.", i, virtualFill, expected[i], v)
+				}
+			}
+		}
+	}
+}
+
+func TestCCSDSCodeblock(t *testing.T) {
+	t.Log("Testing CCSDS codeblocks with interleaving and virtual fill")
+
+	tests := []struct {
+		depth       int
+		virtualFill int
+	}{
+		{1, 0},
+		{5, 0},
+		{8, 0},
+		{4, 100},
+	}
+
+	for _, test := range tests {
+		c, err := NewCCSDSProfile(test.depth, test.virtualFill)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.CodeblockLength() != test.depth*(255-test.virtualFill) {
+			t.Errorf("The codeblock was expected to have %d symbols, but it had %d instead.", test.depth*(255-test.virtualFill), c.CodeblockLength())
+		}
+
+		data := make([]int, c.DataLength())
+		for i := range data {
+			data[i] = (i*7 + 3) % 256
+		}
+		codeblock, err := c.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A burst of 11 symbols per codeword, and 10 erasures per codeword after it
+		for i := 0; i < 11*test.depth; i++ {
+			codeblock[10+i] ^= 0x5A
+		}
+		erasures := []int{}
+		for i := 0; i < 10*test.depth; i++ {
+			erasures = append(erasures, c.CodeblockLength()-1-i)
+			codeblock[c.CodeblockLength()-1-i] = 0
+		}
+
+		resp, stats, err := c.Decode(codeblock, erasures)
+		if err != nil {
+			t.Fatalf("Could not decode depth %d: %s", test.depth, err)
+		}
+		for j, s := range stats {
+			if s.Corrected != 21 {
+				t.Errorf("Codeword %d was expected to have 21 corrections, but it had %d instead.", j, s.Corrected)
+			}
+		}
+		for i, r := range resp {
+			if r != data[i] {
+				t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], r)
+			}
+		}
+	}
+
+	if _, err := NewCCSDSProfile(9, 0); err == nil {
+		t.Error("Should have stated that the interleaving depth is invalid")
+	}
+}
@@ -0,0 +1,273 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Cross-Interleaved Reed-Solomon Code (CIRC) used by audio CDs (ECMA-130 / IEC 60908).
+// Every frame of 24 data bytes (6 stereo samples of 16 bits) is protected by two shortened RS codes over GF(256)
+// (prim 285, fcr 0) separated by delay lines:
+//  1. the even samples are delayed by 2 frames and the words are regrouped (even samples first, odd samples last)
+//  2. C2 (28,24) adds 4 Q parity bytes in the middle of the frame (bytes 12 to 15)
+//  3. byte i of the C2 codeword is delayed by i*4 frames
+//  4. C1 (32,28) adds 4 P parity bytes at the end of the frame
+//  5. the even bytes are delayed by 1 frame and the parity bytes are inverted
+//
+// When decoding, C1 corrects single errors. C1 codewords with more errors are flagged and their bytes are
+// passed to C2 as erasures. After the delay lines a burst of corrupted frames only costs one byte per C2 codeword.
+const (
+	circDataBytes   = 24  // data bytes in a frame
+	circC2Bytes     = 28  // length of a C2 codeword
+	circFrameBytes  = 32  // length of a C1 codeword (a frame)
+	circEccSymbols  = 4   // ecc symbols of both C1 and C2
+	circDelay       = 4   // D: unit delay of the delay lines between C2 and C1 (in frames)
+	circLatency     = 111 // frames between a data frame entering the encoder and leaving the decoder (2 + 27*D + 1)
+	circC1MaxErrors = 1   // C1 codewords with more errors are flagged as erasures for C2
+)
+
+// Index of the 16-bit word of the data frame stored at each pair of C2 data bytes.
+// The even samples (L0, R0, L2, R2, L4, R4) come first, they are also the ones delayed by 2 frames.
+var circWordOrder = [12]int{0, 1, 4, 5, 8, 9, 2, 3, 6, 7, 10, 11}
+
+// CIRCStats reports the outcome of decoding CIRC frames
+type CIRCStats struct {
+	C1Corrected int // C1 codewords with corrected errors
+	C1Failed    int // C1 codewords flagged as erasures for C2
+	C2Corrected int // C2 codewords with corrected errors or erasures
+	C2Failed    int // C2 codewords that could not be corrected
+}
+
+// EncodeCIRC encodes audio data (a multiple of 24 bytes) into CIRC frames of 32 bytes.
+// To flush the delay lines the output holds 111 frames more than the input.
+// The galois field look up tables must be initialized with InitGaloisFields(285, 0).
+func EncodeCIRC(data []byte) ([]byte, error) {
+	if !isGaloisField(285, 0) {
+		return []byte{}, errors.New("CIRC requires the galois field tables to be initialized with prim 285 and fcr 0")
+	}
+	if len(data)%circDataBytes != 0 {
+		return []byte{}, fmt.Errorf("Data length (%d) must be a multiple of %d", len(data), circDataBytes)
+	}
+
+	dataFrames := len(data) / circDataBytes
+	frames := dataFrames + circLatency
+
+	// Steps 1 and 2: regroup the words and compute the C2 codewords (data outside of the input is 0)
+	c2Words := make([][]int, frames)
+	for t := range c2Words {
+		word := make([]int, circC2Bytes)
+		for k := 0; k < circDataBytes; k++ {
+			f := t
+			if k < circDataBytes/2 {
+				f = t - 2 // even samples are delayed by 2 frames
+			}
+			if f >= 0 && f < dataFrames {
+				word[circC2Position(k)] = int(data[f*circDataBytes+2*circWordOrder[k/2]+k%2])
+			}
+		}
+		c2Words[t] = circEncodeC2(word)
+	}
+
+	// Steps 3 to 5: delay lines, C1 codewords and the final 1 frame delay
+	c1Generator := generatorPolynomial(circEccSymbols)
+	previous := make([]int, circFrameBytes) // C1 codeword of the previous frame
+	frameOut := make([]byte, frames*circFrameBytes)
+
+	for t := 0; t < frames; t++ {
+		word := make([]int, circC2Bytes)
+		for i := range word {
+			if t-circDelay*i >= 0 {
+				word[i] = c2Words[t-circDelay*i][i]
+			}
+		}
+
+		c1Word := encodeMessage(word, c1Generator)
+		circInvertParity(c1Word)
+
+		for j := 0; j < circFrameBytes; j++ {
+			if j%2 == 0 {
+				frameOut[t*circFrameBytes+j] = byte(previous[j]) // even bytes are delayed by 1 frame
+			} else {
+				frameOut[t*circFrameBytes+j] = byte(c1Word[j])
+			}
+		}
+		previous = c1Word
+	}
+
+	return frameOut, nil
+}
+
+// DecodeCIRC corrects CIRC frames (as produced by EncodeCIRC) and returns the audio data, which is 111 frames shorter.
+// erasedIndices are positions in frames known to be unreliable (for example from the EFM demodulator).
+// The positions of the data bytes that could not be corrected are returned so that they can be concealed (interpolated).
+// The galois field look up tables must be initialized with InitGaloisFields(285, 0).
+func DecodeCIRC(frames []byte, erasedIndices []int) ([]byte, []int, CIRCStats, error) {
+	stats := CIRCStats{}
+	if !isGaloisField(285, 0) {
+		return []byte{}, []int{}, stats, errors.New("CIRC requires the galois field tables to be initialized with prim 285 and fcr 0")
+	}
+	if len(frames)%circFrameBytes != 0 || len(frames)/circFrameBytes <= circLatency {
+		return []byte{}, []int{}, stats, fmt.Errorf("Invalid number of frames (%d bytes when a multiple of %d bytes with more than %d frames is required)", len(frames), circFrameBytes, circLatency)
+	}
+
+	erased := make([]bool, len(frames))
+	for _, p := range erasedIndices {
+		if p < 0 || p >= len(frames) {
+			return []byte{}, []int{}, stats, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+		erased[p] = true
+	}
+
+	numFrames := len(frames) / circFrameBytes
+	dataFrames := numFrames - circLatency
+
+	// Undo the 1 frame delay and the inversion, then decode C1
+	c1Words := make([][]int, numFrames-1)
+	c1Flags := make([]bool, numFrames-1)
+
+	for t := range c1Words {
+		word := make([]int, circFrameBytes)
+		erasures := []int{}
+		for j := range word {
+			p := t*circFrameBytes + j
+			if j%2 == 0 {
+				p += circFrameBytes // even bytes were delayed by 1 frame
+			}
+			word[j] = int(frames[p])
+			if erased[p] {
+				erasures = append(erasures, j)
+			}
+		}
+		circInvertParity(word)
+
+		c1Words[t], c1Flags[t] = circDecodeC1(word, erasures, &stats)
+	}
+
+	// Undo the delay lines and decode C2
+	c2Words := make([][]int, dataFrames+2)
+	c2Flags := make([]bool, dataFrames+2)
+
+	for t := range c2Words {
+		word := make([]int, circC2Bytes)
+		erasures := []int{}
+		for i := range word {
+			word[i] = c1Words[t+circDelay*i][i]
+			if c1Flags[t+circDelay*i] {
+				erasures = append(erasures, i)
+			}
+		}
+
+		c2Words[t], c2Flags[t] = circDecodeC2(word, erasures, &stats)
+	}
+
+	// Undo the regrouping and the 2 frame delay of the even samples
+	dataOut := make([]byte, dataFrames*circDataBytes)
+	uncorrectable := []int{}
+
+	for f := 0; f < dataFrames; f++ {
+		for k := 0; k < circDataBytes; k++ {
+			t := f
+			if k < circDataBytes/2 {
+				t = f + 2
+			}
+			p := f*circDataBytes + 2*circWordOrder[k/2] + k%2
+			dataOut[p] = byte(c2Words[t][circC2Position(k)])
+			if c2Flags[t] {
+				uncorrectable = append(uncorrectable, p)
+			}
+		}
+	}
+
+	return dataOut, uncorrectable, stats, nil
+}
+
+// Position in the C2 codeword of the kth data byte (the Q parity bytes are in the middle)
+func circC2Position(k int) int {
+	if k < circDataBytes/2 {
+		return k
+	}
+	return k + circEccSymbols
+}
+
+// The Q and P parity bytes are inverted on the disc (so that silence does not produce a frame full of 0's)
+func circInvertParity(word []int) {
+	for i := circDataBytes / 2; i < circDataBytes/2+circEccSymbols; i++ {
+		word[i] ^= 0xFF
+	}
+	for i := circC2Bytes; i < circFrameBytes; i++ {
+		word[i] ^= 0xFF
+	}
+}
+
+// C2 has its parity in the middle, so it can't be encoded by appending the ecc symbols.
+// Instead the parity bytes are treated as erasures, and the decoder finds the values that make the codeword valid.
+func circEncodeC2(word []int) []int {
+	erasedIndices := []int{}
+	for i := 0; i < circEccSymbols; i++ {
+		erasedIndices = append(erasedIndices, circDataBytes/2+i)
+	}
+
+	correctedMsg, correctedEcc, _ := Decode(word, circEccSymbols, erasedIndices) // as many erasures as ecc symbols can always be corrected
+	return append(correctedMsg, correctedEcc...)
+}
+
+// Decode a C1 codeword, returning its first 28 bytes and whether it has to be flagged as erasures for C2
+func circDecodeC1(word, erasedIndices []int, stats *CIRCStats) ([]int, bool) {
+	received := make([]int, len(word))
+	copy(received, word)
+
+	correctedMsg, correctedEcc, err := Decode(word, circEccSymbols, erasedIndices)
+	if err != nil {
+		stats.C1Failed++
+		return received[:circC2Bytes], true
+	}
+
+	// Count the errors (corrected symbols that were not erasures)
+	corrected := append(correctedMsg, correctedEcc...)
+	errorCount := 0
+	for i := range received {
+		if received[i] != corrected[i] && !containsInt(erasedIndices, i) {
+			errorCount++
+		}
+	}
+	if errorCount > circC1MaxErrors { // too likely to be a miscorrection, let C2 handle it
+		stats.C1Failed++
+		return received[:circC2Bytes], true
+	}
+
+	if errorCount > 0 || len(erasedIndices) > 0 {
+		stats.C1Corrected++
+	}
+	return corrected[:circC2Bytes], false
+}
+
+// Decode a C2 codeword, returning it and whether it could not be corrected
+func circDecodeC2(word, erasedIndices []int, stats *CIRCStats) ([]int, bool) {
+	received := make([]int, len(word))
+	copy(received, word)
+
+	if len(erasedIndices) > circEccSymbols {
+		erasedIndices = []int{} // too many flags to use them, try to find the errors instead
+	}
+
+	correctedMsg, correctedEcc, err := Decode(word, circEccSymbols, erasedIndices)
+	if err != nil {
+		stats.C2Failed++
+		return received, true
+	}
+
+	corrected := append(correctedMsg, correctedEcc...)
+	if countCorrections(received, corrected) > 0 {
+		stats.C2Corrected++
+	}
+	return corrected, false
+}
+
+func containsInt(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
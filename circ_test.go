@@ -0,0 +1,158 @@
+package reedSolomon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func makeTestAudio(frames int) []byte {
+	data := make([]byte, frames*circDataBytes)
+	for i := range data {
+		data[i] = byte(i*37 + i/24)
+	}
+	return data
+}
+
+func TestCIRCRequiresField(t *testing.T) {
+	t.Log("Testing that CIRC requires prim 285 and fcr 0")
+
+	// The tests are initialized with 301, 1
+	if _, err := EncodeCIRC(makeTestAudio(1)); err == nil {
+		t.Error("Should have stated that the galois field tables are not initialized correctly")
+	}
+}
+
+func TestCIRCEncode(t *testing.T) {
+	t.Log("Testing CIRC encoding")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	frames, err := EncodeCIRC(makeTestAudio(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != (10+circLatency)*circFrameBytes {
+		t.Fatalf("Expected %d bytes, but there were %d instead.", (10+circLatency)*circFrameBytes, len(frames))
+	}
+
+	// Silence produces frames with only the inverted parity bytes set
+	silence, _ := EncodeCIRC(make([]byte, circDataBytes))
+	for j := 1; j < circFrameBytes; j += 2 {
+		expected := byte(0)
+		if (j >= 12 && j < 16) || j >= 28 {
+			expected = 0xFF
+		}
+		if silence[5*circFrameBytes+j] != expected {
+			t.Errorf("Byte %d of a silent frame was expected to be %d, but it was %d instead.", j, expected, silence[5*circFrameBytes+j])
+		}
+	}
+
+	if _, err := EncodeCIRC(make([]byte, 25)); err == nil {
+		t.Error("Should have stated that the data length is invalid")
+	}
+}
+
+func TestCIRCDecodeClean(t *testing.T) {
+	t.Log("Testing CIRC decoding without errors")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	data := makeTestAudio(20)
+	frames, _ := EncodeCIRC(data)
+
+	decoded, uncorrectable, stats, err := DecodeCIRC(frames, []int{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Error("Decoded data does not match the original data")
+	}
+	if len(uncorrectable) != 0 || stats != (CIRCStats{}) {
+		t.Errorf("Expected no corrections, but got %+v and %d uncorrectable bytes instead.", stats, len(uncorrectable))
+	}
+}
+
+func TestCIRCDecodeRandomErrors(t *testing.T) {
+	t.Log("Testing CIRC decoding with scattered errors")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	data := makeTestAudio(20)
+	frames, _ := EncodeCIRC(data)
+
+	// Single errors are corrected by C1, double errors are passed on to C2.
+	// Adjacent bytes end up in different C1 codewords (the even bytes are delayed by 1 frame), so the errors
+	// of the second C1 codeword are 2 odd bytes.
+	frames[40*circFrameBytes+3] ^= 0x55
+	frames[50*circFrameBytes+9] ^= 0x01
+	frames[50*circFrameBytes+11] ^= 0x80
+
+	decoded, uncorrectable, stats, err := DecodeCIRC(frames, []int{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Error("Decoded data does not match the original data")
+	}
+	if len(uncorrectable) != 0 {
+		t.Errorf("Expected no uncorrectable bytes, but there were %d instead.", len(uncorrectable))
+	}
+	if stats.C1Corrected != 1 || stats.C1Failed != 1 || stats.C2Corrected != 2 {
+		t.Errorf("Expected 1 corrected and 1 failed C1 codewords, but got %+v instead.", stats)
+	}
+}
+
+func TestCIRCDecodeBurst(t *testing.T) {
+	t.Log("Testing CIRC decoding with a long burst of errors")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	data := makeTestAudio(40)
+	frames, _ := EncodeCIRC(data)
+
+	// A scratch wiping out 14 consecutive frames (448 bytes): every C1 codeword fails,
+	// but each C2 codeword gets at most 4 erasures thanks to the delay lines.
+	for p := 60 * circFrameBytes; p < 74*circFrameBytes; p++ {
+		frames[p] = 0
+	}
+
+	decoded, uncorrectable, stats, err := DecodeCIRC(frames, []int{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Error("Decoded data does not match the original data")
+	}
+	if len(uncorrectable) != 0 || stats.C2Failed != 0 {
+		t.Errorf("Expected no uncorrectable bytes, but got %+v and %d uncorrectable bytes instead.", stats, len(uncorrectable))
+	}
+	if stats.C1Failed == 0 || stats.C2Corrected == 0 {
+		t.Errorf("Expected C1 failures corrected by C2, but got %+v instead.", stats)
+	}
+}
+
+func TestCIRCDecodeUncorrectable(t *testing.T) {
+	t.Log("Testing CIRC decoding with a burst that is too long")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	data := makeTestAudio(40)
+	frames, _ := EncodeCIRC(data)
+
+	for p := 60 * circFrameBytes; p < 90*circFrameBytes; p++ {
+		frames[p] ^= byte(p)
+	}
+
+	_, uncorrectable, stats, err := DecodeCIRC(frames, []int{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uncorrectable) == 0 || stats.C2Failed == 0 {
+		t.Errorf("Expected uncorrectable bytes, but got %+v instead.", stats)
+	}
+}
@@ -0,0 +1,136 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ECC200 symbol sizes: total data and ecc codewords, and the number of interleaved blocks
+var dataMatrixSymbols = []struct {
+	rows, columns     int
+	dataCodewords     int
+	eccCodewords      int
+	interleavedBlocks int
+}{
+	// Square symbols
+	{10, 10, 3, 5, 1},
+	{12, 12, 5, 7, 1},
+	{14, 14, 8, 10, 1},
+	{16, 16, 12, 12, 1},
+	{18, 18, 18, 14, 1},
+	{20, 20, 22, 18, 1},
+	{22, 22, 30, 20, 1},
+	{24, 24, 36, 24, 1},
+	{26, 26, 44, 28, 1},
+	{32, 32, 62, 36, 1},
+	{36, 36, 86, 42, 1},
+	{40, 40, 114, 48, 1},
+	{44, 44, 144, 56, 1},
+	{48, 48, 174, 68, 1},
+	{52, 52, 204, 84, 2},
+	{64, 64, 280, 112, 2},
+	{72, 72, 368, 144, 4},
+	{80, 80, 456, 192, 4},
+	{88, 88, 576, 224, 4},
+	{96, 96, 696, 272, 4},
+	{104, 104, 816, 336, 6},
+	{120, 120, 1050, 408, 6},
+	{132, 132, 1304, 496, 8},
+	{144, 144, 1558, 620, 10},
+	// Rectangular symbols
+	{8, 18, 5, 7, 1},
+	{8, 32, 10, 11, 1},
+	{12, 26, 16, 14, 1},
+	{12, 36, 22, 18, 1},
+	{16, 36, 32, 24, 1},
+	{16, 48, 49, 28, 1},
+}
+
+// DataMatrixProfile describes the error correction of an ECC200 Data Matrix symbol size: how many blocks there are,
+// how many data and ecc codewords each of them holds and how they are interleaved in the symbol.
+// The galois field look up tables must be initialized with InitGaloisFields(301, 1).
+type DataMatrixProfile struct {
+	Rows    int
+	Columns int
+	layout  *interleavedLayout
+}
+
+// NewDataMatrixProfile creates the error correction profile of an ECC200 symbol of rows x columns modules
+func NewDataMatrixProfile(rows, columns int) (*DataMatrixProfile, error) {
+	for _, symbol := range dataMatrixSymbols {
+		if symbol.rows != rows || symbol.columns != columns {
+			continue
+		}
+
+		numBlocks := symbol.interleavedBlocks
+		numberEccSymbols := symbol.eccCodewords / numBlocks
+
+		// The data codewords are shared evenly, the first blocks get the remaining ones (only 144x144 has some)
+		dataLengths := make([]int, numBlocks)
+		for b := range dataLengths {
+			dataLengths[b] = symbol.dataCodewords / numBlocks
+			if b < symbol.dataCodewords%numBlocks {
+				dataLengths[b]++
+			}
+		}
+
+		// The data codewords are in the same order in the message as in the symbol: codeword i of block b is at i*numBlocks + b
+		l := columnInterleavedLayout(dataLengths, numberEccSymbols)
+		l.interleavedData = true
+
+		if rows == 144 {
+			// Special case: in the 144x144 symbol the ecc codewords of the 2 shorter blocks (8 and 9) come first in every row
+			p := l.dataLength()
+			for i := 0; i < numberEccSymbols; i++ {
+				for j := 0; j < numBlocks; j++ {
+					b := (j + 8) % numBlocks
+					l.positions[b][dataLengths[b]+i] = p
+					p++
+				}
+			}
+		}
+
+		return &DataMatrixProfile{Rows: rows, Columns: columns, layout: l}, nil
+	}
+
+	return nil, fmt.Errorf("Invalid Data Matrix symbol size %dx%d", rows, columns)
+}
+
+// TotalCodewords returns the number of codewords (data and ecc) in the symbol
+func (d *DataMatrixProfile) TotalCodewords() int {
+	return d.layout.length()
+}
+
+// DataCodewords returns the number of data codewords in the symbol
+func (d *DataMatrixProfile) DataCodewords() int {
+	return d.layout.dataLength()
+}
+
+// EccCodewordsPerBlock returns the number of ecc codewords of every block
+func (d *DataMatrixProfile) EccCodewordsPerBlock() int {
+	return d.layout.numberEccSymbols
+}
+
+// Blocks returns the number of data codewords of every block
+func (d *DataMatrixProfile) Blocks() []int {
+	return append([]int{}, d.layout.dataLengths...)
+}
+
+// Encode splits the data codewords into blocks, computes their ecc codewords and returns the interleaved codewords
+// in the order they are placed in the symbol.
+func (d *DataMatrixProfile) Encode(data []int) ([]int, error) {
+	if !isGaloisField(301, 1) {
+		return []int{}, errors.New("Data Matrix requires the galois field tables to be initialized with prim 301 and fcr 1")
+	}
+	return d.layout.encode(data)
+}
+
+// Decode de-interleaves the codewords read from the symbol, corrects every block and returns the data codewords.
+// erasedIndices are positions in the codeword stream (for example unreadable modules).
+// Blocks that can not be corrected are returned as received and the first failure is returned as the error.
+func (d *DataMatrixProfile) Decode(codewords []int, erasedIndices []int) ([]int, []BlockStats, error) {
+	if !isGaloisField(301, 1) {
+		return []int{}, []BlockStats{}, errors.New("Data Matrix requires the galois field tables to be initialized with prim 301 and fcr 1")
+	}
+	return d.layout.decode(codewords, erasedIndices)
+}
@@ -0,0 +1,146 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+func TestDataMatrixProfileTables(t *testing.T) {
+	t.Log("Testing Data Matrix symbol size tables")
+
+	tests := []struct {
+		rows, columns int
+		total         int
+		ecc           int
+		blocks        []int
+	}{
+		{10, 10, 8, 5, []int{3}},
+		{16, 48, 77, 28, []int{49}},
+		{52, 52, 288, 42, []int{102, 102}},
+		{144, 144, 2178, 62, []int{156, 156, 156, 156, 156, 156, 156, 156, 155, 155}},
+	}
+
+	for _, test := range tests {
+		d, err := NewDataMatrixProfile(test.rows, test.columns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.TotalCodewords() != test.total {
+			t.Errorf("%dx%d was expected to have %d codewords, but it had %d instead.", test.rows, test.columns, test.total, d.TotalCodewords())
+		}
+		if d.EccCodewordsPerBlock() != test.ecc {
+			t.Errorf("%dx%d was expected to have %d ecc codewords per block, but it had %d instead.", test.rows, test.columns, test.ecc, d.EccCodewordsPerBlock())
+		}
+		blocks := d.Blocks()
+		if len(blocks) != len(test.blocks) {
+			t.Fatalf("%dx%d was expected to have %d blocks, but it had %d instead.", test.rows, test.columns, len(test.blocks), len(blocks))
+		}
+		for i, n := range test.blocks {
+			if blocks[i] != n {
+				t.Errorf("Block %d of %dx%d was expected to have %d data codewords, but it had %d instead.", i, test.rows, test.columns, n, blocks[i])
+			}
+		}
+	}
+
+	if _, err := NewDataMatrixProfile(11, 11); err == nil {
+		t.Error("Should have stated that the symbol size is invalid")
+	}
+}
+
+func TestDataMatrixProfileEncode(t *testing.T) {
+	t.Log("Testing Data Matrix encoding")
+
+	// "123456" in a 10x10 symbol
+	d, _ := NewDataMatrixProfile(10, 10)
+
+	expected := []int{142, 164, 186, 114, 25, 5, 88, 102}
+	resp, err := d.Encode([]int{142, 164, 186})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+}
+
+func TestDataMatrixProfileInterleaving(t *testing.T) {
+	t.Log("Testing Data Matrix block interleaving")
+
+	d, _ := NewDataMatrixProfile(144, 144)
+	data := make([]int, d.DataCodewords())
+	for i := range data {
+		data[i] = i % 256
+	}
+
+	resp, err := d.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The data codewords stay in place
+	for i := range data {
+		if resp[i] != data[i] {
+			t.Fatalf("Data codeword %d was expected to be %d, but it was %d instead.", i, data[i], resp[i])
+		}
+	}
+
+	// Block 8 holds data codewords 8, 18, 28, ... and its ecc codewords come first in every row
+	block := []int{}
+	for i := 0; i < 155; i++ {
+		block = append(block, data[i*10+8])
+	}
+	codeword, _ := Encode(block, 62)
+	if resp[1558] != codeword[155] || resp[1568] != codeword[156] {
+		t.Error("The ecc codewords of block 8 were expected to come first in every row")
+	}
+}
+
+func TestDataMatrixProfileDecode(t *testing.T) {
+	t.Log("Testing Data Matrix decoding with damaged codewords")
+
+	d, _ := NewDataMatrixProfile(64, 64) // 2 blocks of 140 data and 56 ecc codewords
+	data := make([]int, d.DataCodewords())
+	for i := range data {
+		data[i] = (i * 13) % 256
+	}
+	codewords, _ := d.Encode(data)
+
+	// 50 consecutive damaged codewords (25 per block) and 10 erasures (5 per block): 55 of 56 per block
+	for p := 20; p < 70; p++ {
+		codewords[p] ^= 0x33
+	}
+	erasures := []int{}
+	for p := 300; p < 310; p++ {
+		codewords[p] = 0
+		erasures = append(erasures, p)
+	}
+
+	decoded, stats, err := d.Decode(codewords, erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range decoded {
+		if r != data[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], r)
+		}
+	}
+	for b, s := range stats {
+		if s.Erasures != 5 {
+			t.Errorf("Block %d was expected to have 5 erasures, but it had %d instead.", b, s.Erasures)
+		}
+	}
+}
+
+func TestDataMatrixRequiresField(t *testing.T) {
+	t.Log("Testing that Data Matrix requires prim 301 and fcr 1")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	d, _ := NewDataMatrixProfile(10, 10)
+	if _, err := d.Encode([]int{1, 2, 3}); err == nil {
+		t.Error("Should have stated that the galois field tables are not initialized correctly")
+	}
+}
@@ -20,30 +20,7 @@ func InitGaloisFields(prim int, firstConsecutiveRoot int) error {
 	// fcr
 	fcr = firstConsecutiveRoot
 
-	// For each possible value in the galois field 2^8, we will pre-compute the logarithm and anti-logarithm (exponential) of this value
-	x := 1
-	for i := 0; i < 255; i++ {
-
-		exponents[i] = x // compute exponents for this value and store it in a table
-		logs[x] = i      // compute log at the same time
-
-		// TODO: if generator=2 use current method (fastest) if not require fast or slow defined in inputs
-		// Slow: Standard carry-less multiplication + modular reduction using an irreducible prime polynomial.
-		// Fast: Russian Peasant Multiplication algorithm
-
-		x <<= 1           // Bitwise multiply by 2 (change 1 by another number y to multiply by a power of 2^y)
-		if x&0x100 != 0 { // similar to x >= 256, but a lot faster (because 0x100 == 256)
-			// Rolls over the value from 256 back to 0 and then up again
-			x ^= prim // subtract the primary polynomial to the current value (instead of 255, so that we get a unique set made of coprime numbers), this is the core of the tables generation
-		}
-	}
-
-	// Double the size of the anti-log table so that we don't need to mod 255 later
-	copy(exponents[255:510], exponents[0:255]) // optimized (vs for loop)
-	// TODO: confirm that this is correct... For loop below is based on reference code
-	//for i := 255; i < 512; i++ {
-	//	exponents[i] = exponents[i-255]
-	//}
+	defaultField().buildTables(prim)
 
 	return nil
 }
@@ -54,100 +31,51 @@ func InitGaloisFields(prim int, firstConsecutiveRoot int) error {
 // Erasures cost 1 each: therefore you can correct as many erasure as the amount of error correction symbols appended to the message
 // IE: if the message has 8 ECC symbols than up to 8 erasure as long as the erased position is provided
 func Decode(msg []int, numberEccSymbols int, erasedIndices []int) ([]int, []int, error) {
-	// Reed-Solomon main decoding function
-
-	if len(msg) > 255 { // can't decode, message is too big
-		return []int{}, []int{}, fmt.Errorf("Message is too long (%d when max is 255)", len(msg))
-	}
-
-	msgOut := msg // copy
-
-	// erasures: set them to null bytes for easier decoding (but this is not necessary, they will be corrected anyway,
-	// but debugging will be easier with null bytes because the error locator polynomial values will
-	// only depend on the errors locations, not their values)
-
-	if len(erasedIndices) == 0 {
-		erasedIndices = []int{}
-	} else {
-		for _, ePos := range erasedIndices {
-			msgOut[ePos] = 0
-		}
-	}
-
-	// check if there are too many erasures to correct (beyond the Singleton bound)
-	if len(erasedIndices) > numberEccSymbols {
-		return []int{}, []int{}, errors.New("Too many erasures to correct")
-	}
+	return defaultField().decode(msg, numberEccSymbols, erasedIndices)
+}
 
-	// prepare the syndrome polynomial using only errors (ie: errors = characters that were either replaced by null byte
-	// or changed to another character, but we don't know their positions)
-	synd := calculateSyndromes(msgOut, numberEccSymbols)
+// ==========================================
+//             Unexported Methods
+// ==========================================
 
-	// check if there's any error/erasure in the input codeword.
-	// If not (all syndromes coefficients are 0), then just return the message as-is.
-	if isSyndromeClean(synd) {
-		m := len(msgOut) - numberEccSymbols
-		return msgOut[:m], msgOut[m:], nil // no errors
+// Reed-Solomon main decoding function, see Decode. The codeword is corrected by a Decoder borrowed from a pool, so
+// that Decode and Decoder share the same implementation, in a copy so that msg is left as received.
+func (gf *galoisField) decode(msg []int, numberEccSymbols int, erasedIndices []int) ([]int, []int, error) {
+	if len(msg) > gf.order { // can't decode, message is too big
+		return []int{}, []int{}, fmt.Errorf("Message is too long (%d when max is %d)", len(msg), gf.order)
 	}
 
-	// compute the Forney syndromes, which hide the erasures from the original syndrome (so that BM will just have to deal with errors, not erasures)
-	fsynd := calcForneySyndromes(synd, erasedIndices, len(msgOut))
-
-	// compute the error locator polynomial using Berlekamp-Massey
-	// NOTE: when using forney syndromes DO NOT pass the erasure positions
-	errLoc, err := unknownErrorLocator(fsynd, []int{}, numberEccSymbols, len(erasedIndices))
+	d, err := gf.borrowDecoder(numberEccSymbols)
 	if err != nil {
 		return []int{}, []int{}, err
 	}
+	defer releaseDecoder(d)
 
-	// locate the message errors using Chien search (or brute-force search)
-	errPos, err := findErrors(sliceIntReverse(errLoc), len(msgOut))
-
-	if err != nil {
-		return []int{}, []int{}, err // error location failed
-	}
-	if len(errPos) == 0 && len(erasedIndices) == 0 {
-		return []int{}, []int{}, errors.New("Could not calculate error positions") // error location failed
-	}
-
-	// Find errors values and apply them to correct the message
-	// compute errata evaluator and errata magnitude polynomials, then correct errors and erasures
-	msgOut = correctErrors(msgOut, synd, append(erasedIndices, errPos...)) // note that we here use the original syndrome, not the forney syndrome
-
-	// (because we will correct both errors and erasures, so we need the full syndrome)
-	// check if the final message is fully repaired
-	synd = calculateSyndromes(msgOut, numberEccSymbols)
-
-	if !isSyndromeClean(synd) {
-		return []int{}, []int{}, errors.New("Could not correct message") // message could not be repaired
-	}
-
-	// return the successfully decoded message
-	m := len(msgOut) - numberEccSymbols
-	return msgOut[:m], msgOut[m:], nil // also return the corrected ecc block so that the user can check()
+	msgOut := make([]int, len(msg))
+	copy(msgOut, msg)
+	return d.Decode(msgOut, erasedIndices)
 }
 
-// ==========================================
-//             Unexported Methods
-// ==========================================
+// Check if the look up tables were initialized (see InitGaloisFields) with the given primitive polynomial and first consecutive root.
+// alpha^8 is the primitive polynomial without its x^8 term, since x^8 = prim - x^8 in GF(2^8).
+func isGaloisField(prim, firstConsecutiveRoot int) bool {
+	return exponents[8] == prim^0x100 && fcr == firstConsecutiveRoot
+}
 
 // Given the received codeword msg and the number of error correcting symbols (nsym), this computes the syndromes polynomial.
 // Mathematically, it's essentially equivalent to a Fourrier Transform (Chien search being the inverse).
-func calculateSyndromes(msg []int, nsym int) []int {
-
-	synd := make([]int, nsym) //  Make an empty syndrome slice <--- this is c
-	//msg = append([]int{0}, msg...)
-	for i := 0; i < nsym; i++ {
-		synd[i] = gfPolynomialEval(msg, gfPower(2, i+fcr)) // TODO: +1 is first consecutive root? might need to change this value for some generators
-	}
+// The syndromes are the evaluations of msg at the roots alpha^(i+fcr) of the generator polynomial, all computed in a
+// single pass over msg (see syndromes).
+func (gf *galoisField) calculateSyndromes(msg []int, nsym int) []int {
 
-	// Here we append a 0 coefficient for the lowest degree (the constant). This effectively shifts the
+	// Here we prepend a 0 coefficient for the lowest degree (the constant). This effectively shifts the
 	// syndrome, and will shift every computations depending on the syndromes (such as the errors locator polynomial,
 	// errors evaluator polynomial, etc. but not the errors positions).
 
 	// This is not necessary, you can adapt subsequent computations to start from 0 instead of skipping the first
 	// iteration (ie, the often seen range(1, n-k+1))
-	synd = append([]int{0}, synd...)
+	synd := make([]int, nsym+1)
+	gf.syndromes(msg, synd)
 	return synd
 }
 
@@ -166,26 +94,26 @@ func isSyndromeClean(synd []int) bool {
 // with xxxxxxxxx being the ecc of length n-k=9, here the string positions are [1, 4], but the coefficients are reversed
 // since the ecc characters are placed as the first coefficients of the polynomial, thus the coefficients of the
 // erased characters are n-1 - [1, 4] = [18, 15] = erasures_loc to be specified as an argument.
-func calcErrorLocatorPolynomial(errorPositions []int) []int {
+func (gf *galoisField) calcErrorLocatorPolynomial(errorPositions []int) []int {
 
 	errorLocatorPolynomial := []int{1} // just to init because we will multiply, so it must be 1 so that the multiplication starts correctly without nulling any term
 	// erasures_loc = product(1 - x*alpha**i) for i in erasures_pos and where alpha is the alpha chosen to evaluate polynomials.
 
 	for _, p := range errorPositions {
-		errorLocatorPolynomial = gfPolynomialMultiplication(errorLocatorPolynomial, gfPolynomialAddition([]int{1}, []int{gfPower(2, p), 0}))
+		errorLocatorPolynomial = gf.polynomialMultiplication(errorLocatorPolynomial, gfPolynomialAddition([]int{1}, []int{gf.alphaPower(p), 0}))
 	}
 	return errorLocatorPolynomial
 }
 
 // Compute the error (or erasures if you supply sigma=erasures locator polynomial, or errata) evaluator polynomial Omega
 // from the syndrome and the error/erasures/errata locator Sigma.
-func calcErrorPolynomial(synd, errorLocatorPolynomial []int, nsym int) []int {
+func (gf *galoisField) calcErrorPolynomial(synd, errorLocatorPolynomial []int, nsym int) []int {
 
 	// Omega(x) = [ Synd(x) * Error_loc(x) ] mod x^(n-k+1)
 	placeholder := make([]int, nsym+1)
 	placeholder = append([]int{1}, placeholder...)
 
-	_, remainder := gfPolynomialDivision(gfPolynomialMultiplication(synd, errorLocatorPolynomial), placeholder) // first multiply syndromes * errata_locator, then do a polynomial division to truncate the polynomial to the required length
+	_, remainder := gf.polynomialDivision(gf.polynomialMultiplication(synd, errorLocatorPolynomial), placeholder) // first multiply syndromes * errata_locator, then do a polynomial division to truncate the polynomial to the required length
 
 	//remainder := gfPolynomialMultiplication(synd, errorLocatorPolynomial) // first multiply the syndromes with the errata locator polynomial
 	// remainder = remainder[len(remainder)-(nsym+1):]                  // then slice the list to truncate it (which represents the polynomial), which
@@ -195,7 +123,7 @@ func calcErrorPolynomial(synd, errorLocatorPolynomial []int, nsym int) []int {
 
 // Find error/errata locator and evaluator polynomials with Berlekamp-Massey algorithm
 // NOTE: If forney syndromes are provided then use and empty erasureLoc slice as the erasures are not part of the forney syndrome
-func unknownErrorLocator(synd, erasureLoc []int, nsym, erasureCount int) ([]int, error) {
+func (gf *galoisField) unknownErrorLocator(synd, erasureLoc []int, nsym, erasureCount int) ([]int, error) {
 
 	// The idea is that BM will iteratively estimate the error locator polynomial.
 	// To do this, it will compute a Discrepancy term called Delta, which will tell us if the error locator polynomial needs an update or not
@@ -232,7 +160,7 @@ func unknownErrorLocator(synd, erasureLoc []int, nsym, erasureCount int) ([]int,
 		delta := synd[K]
 
 		for j := 1; j < len(errLoc); j++ {
-			delta ^= gfMultiplication(errLoc[len(errLoc)-(j+1)], synd[K-j]) // delta is also called discrepancy. Here we do a partial polynomial multiplication (ie, we compute the polynomial multiplication only for the term of degree K). Should be equivalent to brownanrs.polynomial.mul_at().
+			delta ^= gf.multiplication(errLoc[len(errLoc)-(j+1)], synd[K-j]) // delta is also called discrepancy. Here we do a partial polynomial multiplication (ie, we compute the polynomial multiplication only for the term of degree K). Should be equivalent to brownanrs.polynomial.mul_at().
 		}
 
 		// Shift polynomials to compute the next degree
@@ -242,13 +170,13 @@ func unknownErrorLocator(synd, erasureLoc []int, nsym, erasureCount int) ([]int,
 		if delta != 0 { // Update only if there's a discrepancy
 			if len(oldLoc) > len(errLoc) { // Rule B (rule A is implicitly defined because rule A just says that we skip any modification for this iteration)
 				// Computing errata locator polynomial Sigma
-				newLoc := gfPolynomialScale(oldLoc, delta)
-				oldLoc = gfPolynomialScale(errLoc, gfInverse(delta)) // effectively we are doing err_loc * 1/delta = err_loc // delta
+				newLoc := gf.polynomialScale(oldLoc, delta)
+				oldLoc = gf.polynomialScale(errLoc, gf.inverse(delta)) // effectively we are doing err_loc * 1/delta = err_loc // delta
 				errLoc = newLoc
 			}
 
 			// Update with the discrepancy
-			errLoc = gfPolynomialAddition(errLoc, gfPolynomialScale(oldLoc, delta))
+			errLoc = gfPolynomialAddition(errLoc, gf.polynomialScale(oldLoc, delta))
 		}
 	}
 
@@ -272,7 +200,7 @@ func unknownErrorLocator(synd, erasureLoc []int, nsym, erasureCount int) ([]int,
 	return errLoc, nil
 }
 
-func correctErrors(msgIn, synd, errPos []int) []int {
+func (gf *galoisField) correctErrors(msgIn, synd, errPos []int) []int {
 	// errPos is a list of the positions of the errors/erasures/errata
 	// Forney algorithm, computes the values (error magnitude) to correct the input message.
 
@@ -285,21 +213,23 @@ func correctErrors(msgIn, synd, errPos []int) []int {
 		coefPos[i] = len(msgIn) - 1 - p
 	}
 
-	errorLocatorPolynomial := calcErrorLocatorPolynomial(coefPos)
+	errorLocatorPolynomial := gf.calcErrorLocatorPolynomial(coefPos)
 	// calculate errata evaluator polynomial (often called Omega or Gamma in academic papers)
 
-	errorPolynomial := calcErrorPolynomial(sliceIntReverse(synd), errorLocatorPolynomial, len(errorLocatorPolynomial)-1)
+	// NOTE: calcErrorPolynomial drops the nsym+1 highest coefficients of Synd(x) * Error_loc(x) (because of how gfPolynomialDivision
+	// splits its output), so the errata count - 1 is used: with the errata count the top term of Omega is lost when it is equal to nsym.
+	errorPolynomial := gf.calcErrorPolynomial(sliceIntReverse(synd), errorLocatorPolynomial, len(errorLocatorPolynomial)-2)
 	//errorPolynomial = sliceIntReverse(errorPolynomial) // reverse the order
 
 	// Second part of Chien search to get the error location polynomial X from the error positions in errPos (the roots of the error locator polynomial, ie, where it evaluates to 0)
 	locationPolynomial := []int{} // will store the position of the errors
 	for i := 0; i < len(coefPos); i++ {
-		l := 255 - coefPos[i]
-		locationPolynomial = append(locationPolynomial, gfPower(2, -l))
+		l := gf.order - coefPos[i]
+		locationPolynomial = append(locationPolynomial, gf.alphaPower(-l))
 	}
 
 	// Forney algorithm: compute the magnitudes
-	E := forney(msgIn, errorPolynomial, locationPolynomial, errPos, fcr)
+	E := gf.forney(msgIn, errorPolynomial, locationPolynomial, errPos)
 
 	// Apply the correction of values to get our message corrected! (note that the ecc bytes also gets corrected!)
 	// (this isn't the Forney algorithm, we just apply the result of decoding here)
@@ -310,7 +240,7 @@ func correctErrors(msgIn, synd, errPos []int) []int {
 
 // Find the roots (ie, where evaluation = zero) of error polynomial by brute-force trial, this is a sort of Chien's search
 // (but less efficient, Chien's search is a way to evaluate the polynomial such that each evaluation only takes constant time).
-func findErrors(errLoc []int, msgLen int) ([]int, error) {
+func (gf *galoisField) findErrors(errLoc []int, msgLen int) ([]int, error) {
 
 	// Find the roots (ie, where evaluation = zero) of error polynomial by brute-force trial, this is a sort of Chien's search
 	// (but less efficient, Chien's search is a way to evaluate the polynomial such that each evaluation only takes constant time).
@@ -318,7 +248,7 @@ func findErrors(errLoc []int, msgLen int) ([]int, error) {
 	errPos := []int{}
 
 	for i := 0; i < msgLen; i++ { // normally we should try all 2^8 possible values, but here we optimize to just check the interesting symbols
-		if gfPolynomialEval(errLoc, gfPower(2, i)) == 0 { // It's a 0? Bingo, it's a root of the error locator polynomial,
+		if gf.polynomialEval(errLoc, gf.alphaPower(i)) == 0 { // It's a 0? Bingo, it's a root of the error locator polynomial,
 			// in other terms this is the location of an error
 			errPos = append(errPos, msgLen-1-i)
 		}
@@ -333,7 +263,7 @@ func findErrors(errLoc []int, msgLen int) ([]int, error) {
 	return errPos, nil
 }
 
-func calcForneySyndromes(synd, pos []int, msgLen int) []int {
+func (gf *galoisField) calcForneySyndromes(synd, pos []int, msgLen int) []int {
 	// Compute Forney syndromes, which computes a modified syndromes to compute only errors (erasures are trimmed out).
 	// Do not confuse this with Forney algorithm, which allows to correct the message based on the location of errors.
 
@@ -349,9 +279,9 @@ func calcForneySyndromes(synd, pos []int, msgLen int) []int {
 	copy(fsynd[:], synd[1:]) // make a copy and trim the first coefficient which is always 0 by definition
 
 	for i := 0; i < len(pos); i++ {
-		x := gfPower(2, erasePosReversed[i])
+		x := gf.alphaPower(erasePosReversed[i])
 		for j := 0; j < len(fsynd)-1; j++ {
-			fsynd[j] = gfMultiplication(fsynd[j], x) ^ fsynd[j+1]
+			fsynd[j] = gf.multiplication(fsynd[j], x) ^ fsynd[j+1]
 		}
 	}
 
@@ -363,3 +293,26 @@ func calcForneySyndromes(synd, pos []int, msgLen int) []int {
 
 	return fsynd
 }
+
+// The same decoding steps on the GF(256) field initialized by InitGaloisFields
+func calculateSyndromes(msg []int, nsym int) []int {
+	return defaultField().calculateSyndromes(msg, nsym)
+}
+func calcErrorLocatorPolynomial(errorPositions []int) []int {
+	return defaultField().calcErrorLocatorPolynomial(errorPositions)
+}
+func calcErrorPolynomial(synd, errorLocatorPolynomial []int, nsym int) []int {
+	return defaultField().calcErrorPolynomial(synd, errorLocatorPolynomial, nsym)
+}
+func unknownErrorLocator(synd, erasureLoc []int, nsym, erasureCount int) ([]int, error) {
+	return defaultField().unknownErrorLocator(synd, erasureLoc, nsym, erasureCount)
+}
+func correctErrors(msgIn, synd, errPos []int) []int {
+	return defaultField().correctErrors(msgIn, synd, errPos)
+}
+func findErrors(errLoc []int, msgLen int) ([]int, error) {
+	return defaultField().findErrors(errLoc, msgLen)
+}
+func calcForneySyndromes(synd, pos []int, msgLen int) []int {
+	return defaultField().calcForneySyndromes(synd, pos, msgLen)
+}
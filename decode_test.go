@@ -389,3 +389,26 @@ func TestCorrectTooManyErasures(t *testing.T) {
 		t.Error("Should have stated: Too many erasures to correct")
 	}
 }
+
+func TestCorrectMessageWithMaxErasures(t *testing.T) {
+	t.Log("Test Correcting Message with as many erasures as ECC symbols")
+
+	msgIn := []int{68, 90, 46, 145, 46, 131, 153, 53, 32, 43, 239, 193, 240, 155, 85, 215, 63, 202}
+	erasurePos := []int{0, 2, 4, 6, 8, 10, 12, 14}
+	for _, p := range erasurePos {
+		msgIn[p] = 0
+	}
+
+	expectedCorrectedMsg := []int{68, 90, 46, 145, 46, 131, 153, 53, 32, 43}
+	correctedMsg, _, err := Decode(msgIn, 8, erasurePos)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	for i, r := range correctedMsg {
+		if r != expectedCorrectedMsg[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expectedCorrectedMsg[i], r)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Decoder decodes codewords with a fixed number of ecc symbols without allocating: the syndromes, the locator
+// polynomials and the errata positions are computed in scratch buffers sized when the Decoder is created.
+// The codeword is corrected in place (Forney syndromes, Berlekamp-Massey, Chien search, Forney algorithm). Decode
+// borrows a Decoder from a pool and gives it a copy of the codeword.
+// A Decoder is not safe for concurrent use, create one per goroutine.
+type Decoder struct {
+	field            galoisField // copied, so that the field a Decoder is borrowed for does not escape to the heap
+	numberEccSymbols int
+
+	synd       []int // syndromes, with the 0 coefficient prepended like calculateSyndromes
+	fsynd      []int // Forney syndromes
+	errLoc     []int // the 3 locator polynomials of Berlekamp-Massey (current, previous and a spare one for rule B)
+	oldLoc     []int
+	spareLoc   []int
+	errata     []int // erasure then error positions in the codeword
+	locations  []int // alpha^(coefficient degree) of every errata
+	omega      []int // errata evaluator polynomial, lowest degree first
+	magnitudes []int // values xored into the codeword, kept to restore it when the correction fails
+}
+
+// NewDecoder creates a Decoder for codewords ending with numberEccSymbols ecc symbols.
+// The galois field look up tables must be initialized (see InitGaloisFields) before the Decoder is created.
+func NewDecoder(numberEccSymbols int) (*Decoder, error) {
+	return newDecoder(defaultField(), numberEccSymbols)
+}
+
+// newDecoder creates a Decoder on another field than the one initialized by InitGaloisFields
+func newDecoder(gf *galoisField, numberEccSymbols int) (*Decoder, error) {
+	if err := gf.checkEccSymbols(numberEccSymbols); err != nil {
+		return nil, err
+	}
+
+	nsym := numberEccSymbols
+	return &Decoder{
+		field:            *gf,
+		numberEccSymbols: nsym,
+		synd:             make([]int, nsym+1),
+		fsynd:            make([]int, nsym),
+		errLoc:           make([]int, nsym+2), // oldLoc grows by one coefficient every iteration of Berlekamp-Massey
+		oldLoc:           make([]int, nsym+2),
+		spareLoc:         make([]int, nsym+2),
+		errata:           make([]int, nsym), // the errors cost 2 and the erasures 1, so there are at most nsym errata
+		locations:        make([]int, nsym),
+		omega:            make([]int, nsym+1),
+		magnitudes:       make([]int, nsym),
+	}, nil
+}
+
+func (gf *galoisField) checkEccSymbols(numberEccSymbols int) error {
+	if numberEccSymbols <= 0 || numberEccSymbols >= gf.order {
+		return fmt.Errorf("Invalid number of ecc symbols (%d when it must be between 1 and %d)", numberEccSymbols, gf.order-1)
+	}
+	return nil
+}
+
+// Decoders used by Decode, one pool per number of ecc symbols: the scratch buffers of a Decoder only depend on it, so
+// a Decoder can be borrowed for any field.
+var decoderPools sync.Map
+
+// Borrow a Decoder of gf from the pool of its number of ecc symbols, give it back with releaseDecoder
+func (gf *galoisField) borrowDecoder(numberEccSymbols int) (*Decoder, error) {
+	if err := gf.checkEccSymbols(numberEccSymbols); err != nil {
+		return nil, err
+	}
+	if pool, ok := decoderPools.Load(numberEccSymbols); ok {
+		if d, ok := pool.(*sync.Pool).Get().(*Decoder); ok {
+			d.field = *gf
+			return d, nil
+		}
+	}
+	return newDecoder(gf, numberEccSymbols)
+}
+
+func releaseDecoder(d *Decoder) {
+	pool, ok := decoderPools.Load(d.numberEccSymbols)
+	if !ok {
+		pool, _ = decoderPools.LoadOrStore(d.numberEccSymbols, &sync.Pool{})
+	}
+	pool.(*sync.Pool).Put(d)
+}
+
+// Decode corrects the errors and erasures of msg in place and returns its message and ecc parts (slices of msg).
+// The erased symbols are set to 0 before decoding. If the codeword can not be corrected the other symbols are left as
+// received and an error is returned.
+func (d *Decoder) Decode(msg []int, erasedIndices []int) ([]int, []int, error) {
+	gf := &d.field
+	nsym := d.numberEccSymbols
+
+	if len(msg) > gf.order {
+		return []int{}, []int{}, fmt.Errorf("Message is too long (%d when max is %d)", len(msg), gf.order)
+	}
+	if len(msg) <= nsym {
+		return []int{}, []int{}, fmt.Errorf("Message is too short (%d symbols when more than %d are required)", len(msg), nsym)
+	}
+	if len(erasedIndices) > nsym {
+		return []int{}, []int{}, errors.New("Too many erasures to correct")
+	}
+	for _, ePos := range erasedIndices {
+		if ePos < 0 || ePos >= len(msg) {
+			return []int{}, []int{}, fmt.Errorf("Erasure position %d is out of range", ePos)
+		}
+		msg[ePos] = 0
+	}
+
+	m := len(msg) - nsym
+	if d.calculateSyndromes(msg) {
+		return msg[:m], msg[m:], nil // no errors
+	}
+	if err := d.correct(msg, erasedIndices); err != nil {
+		return []int{}, []int{}, err
+	}
+	return msg[:m], msg[m:], nil
+}
+
+// Correct msg in place from its syndromes, which must already be in d.synd and not be all 0 (Reconstruct of
+// ShardEncoder computes them for all the byte offsets of the shards at once). The erased symbols must be 0.
+func (d *Decoder) correct(msg []int, erasedIndices []int) error {
+	d.calcForneySyndromes(erasedIndices, len(msg))
+
+	errLoc, err := d.unknownErrorLocator(len(erasedIndices))
+	if err != nil {
+		return err
+	}
+
+	errata := append(d.errata[:0], erasedIndices...)
+	errata, err = d.findErrors(errLoc, errata, len(msg))
+	if err != nil {
+		return err
+	}
+	if len(errata) == 0 {
+		return errors.New("Could not calculate error positions")
+	}
+
+	if err := d.correctErrors(msg, errata); err != nil {
+		return err
+	}
+
+	// check if the final message is fully repaired, else put back the symbols as received
+	if !d.calculateSyndromes(msg) {
+		for i, p := range errata {
+			msg[p] ^= d.magnitudes[i]
+		}
+		return errors.New("Could not correct message")
+	}
+	return nil
+}
+
+// Compute the syndromes of msg into d.synd (see calculateSyndromes) and report if they are all 0
+func (d *Decoder) calculateSyndromes(msg []int) bool {
+	return d.field.syndromes(msg, d.synd)
+}
+
+// Compute the Forney syndromes into d.fsynd, which hide the erasures from the syndromes (see calcForneySyndromes)
+func (d *Decoder) calcForneySyndromes(erasedIndices []int, msgLen int) {
+	gf := &d.field
+	fsynd := d.fsynd
+	copy(fsynd, d.synd[1:])
+
+	for _, p := range erasedIndices {
+		x := gf.alphaPower(msgLen - 1 - p)
+		for j := 0; j < len(fsynd)-1; j++ {
+			fsynd[j] = gf.multiplication(fsynd[j], x) ^ fsynd[j+1]
+		}
+	}
+}
+
+// Find the error locator polynomial from the Forney syndromes with Berlekamp-Massey (see unknownErrorLocator).
+// The polynomials go from the biggest to the lowest degree, the result is a slice of one of the scratch buffers.
+func (d *Decoder) unknownErrorLocator(erasureCount int) ([]int, error) {
+	gf := &d.field
+	nsym := d.numberEccSymbols
+	synd := d.fsynd
+
+	errLoc := append(d.errLoc[:0], 1)
+	oldLoc := append(d.oldLoc[:0], 1)
+	spareLoc := d.spareLoc[:0]
+
+	for K := 0; K < nsym-erasureCount; K++ {
+		// Compute the discrepancy Delta
+		delta := synd[K]
+		for j := 1; j < len(errLoc); j++ {
+			delta ^= gf.multiplication(errLoc[len(errLoc)-(j+1)], synd[K-j])
+		}
+
+		// Shift polynomials to compute the next degree
+		oldLoc = append(oldLoc, 0)
+
+		if delta != 0 {
+			if len(oldLoc) > len(errLoc) { // Rule B
+				newLoc := spareLoc[:len(oldLoc)]
+				for i, c := range oldLoc {
+					newLoc[i] = gf.multiplication(c, delta)
+				}
+
+				// oldLoc = errLoc / delta, in the buffer of oldLoc which is not needed anymore
+				inverse := gf.inverse(delta)
+				oldLoc = oldLoc[:len(errLoc)]
+				for i, c := range errLoc {
+					oldLoc[i] = gf.multiplication(c, inverse)
+				}
+
+				spareLoc = errLoc[:0]
+				errLoc = newLoc
+			}
+
+			// Update with the discrepancy, oldLoc is never longer than errLoc here
+			offset := len(errLoc) - len(oldLoc)
+			for i, c := range oldLoc {
+				errLoc[offset+i] ^= gf.multiplication(c, delta)
+			}
+		}
+	}
+
+	for len(errLoc) > 0 && errLoc[0] == 0 {
+		errLoc = errLoc[1:] // drop leading 0s, else errs will not be of the correct size
+	}
+
+	// Errors cost 2 each, erasures 1 each
+	errs := len(errLoc) - 1
+	if errs*2+erasureCount > nsym {
+		return []int{}, fmt.Errorf("Too many errors to correct: %d of max %d (Found at least %d errors and %d erasures)", errs*2+erasureCount, nsym, errs, erasureCount)
+	}
+
+	return errLoc, nil
+}
+
+// Find the roots of the error locator polynomial by brute-force trial (see findErrors) and append the error positions to errata
+func (d *Decoder) findErrors(errLoc, errata []int, msgLen int) ([]int, error) {
+	gf := &d.field
+	errLoc = sliceIntReverse(errLoc)
+	errs := len(errLoc) - 1
+
+	found := 0
+	for i := 0; i < msgLen; i++ {
+		if gf.polynomialEval(errLoc, gf.alphaPower(i)) == 0 {
+			found++
+			if found > errs {
+				break
+			}
+			errata = append(errata, msgLen-1-i)
+		}
+	}
+
+	if found != errs {
+		return errata, errors.New("too many (or few) errors found by Chien Search for the errata locator polynomial")
+	}
+	return errata, nil
+}
+
+// Compute the errata magnitudes with the Forney algorithm (see correctErrors and forney) and xor them into msg
+func (d *Decoder) correctErrors(msg, errata []int) error {
+	gf := &d.field
+	e := len(errata)
+
+	// X_i = alpha^(coefficient degree of the ith errata)
+	locations := d.locations[:e]
+	for i, p := range errata {
+		locations[i] = gf.alphaPower(len(msg) - 1 - p)
+	}
+
+	// The errata locator polynomial prod(1 + X_i x), lowest degree first, in a locator buffer (Berlekamp-Massey is done)
+	errLoc := d.spareLoc[:e+1]
+	errLoc[0] = 1
+	for i, x := range locations {
+		errLoc[i+1] = 0
+		for j := i + 1; j > 0; j-- {
+			errLoc[j] ^= gf.multiplication(errLoc[j-1], x)
+		}
+	}
+
+	// Omega(x) = [ Synd(x) * Errata_loc(x) ] mod x^(e+1), the syndrome polynomial being synd[k] x^k
+	// (its 0 constant coefficient shifts Omega by one degree)
+	omega := d.omega[:e+1]
+	for k := range omega {
+		omega[k] = 0
+		for b := 0; b <= k; b++ {
+			omega[k] ^= gf.multiplication(d.synd[k-b], errLoc[b])
+		}
+	}
+
+	magnitudes := d.magnitudes[:e]
+	for i, location := range locations {
+		locationInverse := gf.inverse(location)
+
+		// The formal derivative of the errata locator (the denominator of the Forney algorithm)
+		errorLocatorPrime := 1
+		for j, l := range locations {
+			if j != i {
+				errorLocatorPrime = gf.multiplication(errorLocatorPrime, 1^gf.multiplication(locationInverse, l))
+			}
+		}
+		if errorLocatorPrime == 0 {
+			return errors.New("Could not correct message")
+		}
+
+		// Evaluate omega at X_i^-1 (Horner's scheme from the biggest degree)
+		y := 0
+		for k := e; k >= 0; k-- {
+			y = gf.multiplication(y, locationInverse) ^ omega[k]
+		}
+		y = gf.multiplication(gf.power(location, 1-gf.fcr), y)
+
+		magnitudes[i], _ = gf.division(y, errorLocatorPrime)
+	}
+
+	for i, p := range errata {
+		msg[p] ^= magnitudes[i]
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package reedSolomon
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDecoder(t *testing.T) {
+	t.Log("Testing the Decoder against Decode with random errors and erasures")
+
+	r := rand.New(rand.NewSource(7))
+	for _, nsym := range []int{2, 8, 32, 64} {
+		d, err := NewDecoder(nsym)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for trial := 0; trial < 200; trial++ {
+			msg := make([]int, 1+r.Intn(255-nsym))
+			for i := range msg {
+				msg[i] = r.Intn(256)
+			}
+			codeword, _ := Encode(msg, nsym)
+
+			// Any mix of errors and erasures within the capacity, and sometimes beyond it
+			erasures := r.Perm(len(codeword))[:r.Intn(nsym+1)]
+			errs := r.Intn((nsym-len(erasures))/2 + 2)
+			received := append([]int{}, codeword...)
+			for _, p := range erasures {
+				received[p] = r.Intn(256)
+			}
+			for _, p := range r.Perm(len(codeword))[:errs] {
+				received[p] ^= 1 + r.Intn(255)
+			}
+
+			input := append([]int{}, received...) // the Decoder corrects received in place
+			respMsg, respEcc, respErr := d.Decode(received, erasures)
+			resp := append(append([]int{}, respMsg...), respEcc...)
+
+			// Beyond the capacity the Decoder may fail or find another codeword
+			if 2*errs+len(erasures) > nsym {
+				if respErr == nil && !isSyndromeClean(calculateSyndromes(resp, nsym)) {
+					t.Fatalf("Decoding %d errors and %d erasures with %d ecc symbols returned an invalid codeword", errs, len(erasures), nsym)
+				}
+				continue
+			}
+
+			expectedMsg, expectedEcc, expectedErr := Decode(input, nsym, erasures)
+			if respErr != nil || expectedErr != nil {
+				t.Fatalf("Decoding %d errors and %d erasures with %d ecc symbols failed: %v (Decode: %v)", errs, len(erasures), nsym, respErr, expectedErr)
+			}
+			expected := append(append([]int{}, expectedMsg...), expectedEcc...)
+			for i, v := range resp {
+				if v != expected[i] || v != codeword[i] {
+					t.Fatalf("Response at index %d was expected to be %d, but it was %d instead.", i, codeword[i], v)
+				}
+			}
+		}
+	}
+}
+
+func TestDecoderAllocations(t *testing.T) {
+	t.Log("Testing that the Decoder does not allocate")
+
+	d, err := NewDecoder(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := make([]int, 200)
+	for i := range msg {
+		msg[i] = i
+	}
+	codeword, _ := Encode(msg, 16)
+	received := make([]int, len(codeword))
+	erasures := []int{3, 50, 180, 214}
+
+	// 6 errors and 4 erasures use all the ecc symbols
+	allocs := testing.AllocsPerRun(100, func() {
+		copy(received, codeword)
+		for _, p := range []int{0, 20, 40, 100, 150, 210} {
+			received[p] ^= 0x5A
+		}
+		if _, _, err := d.Decode(received, erasures); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Decoding with errors and erasures was expected to make no allocations, but it made %.1f instead.", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		copy(received, codeword)
+		if _, _, err := d.Decode(received, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Decoding a correct codeword was expected to make no allocations, but it made %.1f instead.", allocs)
+	}
+}
+
+func TestDecoderTooManyErrors(t *testing.T) {
+	t.Log("Testing that the Decoder leaves an uncorrectable codeword as received")
+
+	d, _ := NewDecoder(8)
+	codeword, _ := Encode([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 8)
+
+	received := append([]int{}, codeword...)
+	for p := 0; p < 5; p++ {
+		received[p] ^= 0xFF
+	}
+	corrupted := append([]int{}, received...)
+
+	if _, _, err := d.Decode(received, nil); err == nil {
+		t.Error("Decoding 5 errors with 8 ecc symbols was expected to fail")
+	}
+	for i, v := range received {
+		if v != corrupted[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, corrupted[i], v)
+		}
+	}
+
+	if _, _, err := d.Decode(received[:8], nil); err == nil {
+		t.Error("A codeword without message symbols was expected to fail")
+	}
+	if _, _, err := d.Decode(received, []int{18}); err == nil {
+		t.Error("An erasure out of the codeword was expected to fail")
+	}
+	if _, err := NewDecoder(0); err == nil {
+		t.Error("A Decoder without ecc symbols was expected to fail")
+	}
+}
+
+func TestDecoderOtherField(t *testing.T) {
+	t.Log("Testing the Decoder on the QR code field (first consecutive root 0)")
+
+	gf := newGaloisField(8, 0x11D, 0)
+	d, err := newDecoder(gf, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []int{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	codeword, _ := gf.encode(msg, 10)
+
+	received := append([]int{}, codeword...)
+	received[1] ^= 0x40
+	received[7] ^= 0x03
+	received[20] ^= 0xFF
+	if _, _, err := d.Decode(received, []int{4, 12, 25}); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range received {
+		if v != codeword[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, codeword[i], v)
+		}
+	}
+}
+
+func TestDecodeBorrowsDecoders(t *testing.T) {
+	t.Log("Testing Decode with Decoders borrowed from a pool")
+
+	// The same number of ecc symbols on fields of different sizes and first consecutive roots
+	fields := []*galoisField{defaultField(), newGaloisField(8, 0x11D, 0), aztecField6, aztecField12, defaultField()}
+	for _, gf := range fields {
+		msg := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		codeword, _ := gf.encode(msg, 6)
+
+		received := append([]int{}, codeword...)
+		received[2] ^= 1
+		received[9] = 0
+		input := append([]int{}, received...)
+
+		respMsg, respEcc, err := gf.decode(received, 6, []int{9, 14})
+		if err != nil {
+			t.Fatalf("Could not decode over GF(%d): %s", gf.order+1, err)
+		}
+		resp := append(append([]int{}, respMsg...), respEcc...)
+		for i, v := range resp {
+			if v != codeword[i] {
+				t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, codeword[i], v)
+			}
+		}
+		for i, v := range received {
+			if v != input[i] {
+				t.Errorf("Decode was expected to leave the codeword as received, but index %d was changed to %d.", i, v)
+			}
+		}
+	}
+}
+
+func TestDecodeAllocations(t *testing.T) {
+	t.Log("Testing that Decode only allocates the copy of the codeword")
+	if raceEnabled {
+		t.Skip("The pools of Decoders are not reliable with the race detector")
+	}
+
+	msg := make([]int, 200)
+	for i := range msg {
+		msg[i] = i
+	}
+	codeword, _ := Encode(msg, 16)
+	received := append([]int{}, codeword...)
+	for _, p := range []int{0, 20, 40, 100, 150, 210} {
+		received[p] ^= 0x5A
+	}
+	erasures := []int{3, 50, 180, 214}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, _, err := Decode(received, 16, erasures); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 1 {
+		t.Errorf("Decoding with errors and erasures was expected to make 1 allocation, but it made %.1f instead.", allocs)
+	}
+}
@@ -0,0 +1,76 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DVB-S and DVB-T (ETSI EN 300 421 / EN 300 744) protect every 188-byte MPEG transport stream packet with RS(204,188),
+// a shortened RS(255,239) over GF(256) with the field polynomial 0x11D and a first consecutive root of 0.
+// The shortened code is obtained by prefixing 51 zero bytes to the packet before encoding, and removing them afterwards.
+var dvbField = newGaloisField(8, 0x11D, 0)
+
+const (
+	dvbPacketBytes    = 188 // MPEG-TS packet
+	dvbCodewordBytes  = 204 // packet followed by its parity bytes
+	dvbEccSymbols     = dvbCodewordBytes - dvbPacketBytes
+	dvbTransportError = 0x80 // transport_error_indicator bit of the second byte of a packet
+)
+
+var dvbCode = &ShortenedCode{length: dvbCodewordBytes, numberEccSymbols: dvbEccSymbols, field: dvbField}
+
+// EncodeDVBPacket returns the 204 bytes of a 188-byte MPEG-TS packet followed by its RS(204,188) parity bytes
+func EncodeDVBPacket(packet []byte) ([]byte, error) {
+	if len(packet) != dvbPacketBytes {
+		return []byte{}, fmt.Errorf("Wrong packet size (%d bytes when %d are expected)", len(packet), dvbPacketBytes)
+	}
+
+	codeword, err := dvbCode.Encode(bytesToSymbols(packet))
+	if err != nil {
+		return []byte{}, err
+	}
+	return symbolsToBytes(codeword), nil
+}
+
+// DecodeDVBPacket corrects up to 8 byte errors of a 204-byte RS(204,188) codeword and returns the 188-byte packet.
+// If the codeword can not be corrected the packet is returned as received along with the error.
+func DecodeDVBPacket(codeword []byte) ([]byte, BlockStats, error) {
+	if len(codeword) != dvbCodewordBytes {
+		return []byte{}, BlockStats{}, fmt.Errorf("Wrong codeword size (%d bytes when %d are expected)", len(codeword), dvbCodewordBytes)
+	}
+
+	packet, stats, err := dvbCode.Decode(bytesToSymbols(codeword), []int{})
+	return symbolsToBytes(packet), stats, err
+}
+
+// DecodeDVBTransportStream reads 204-byte RS(204,188) codewords until EOF, corrects them and writes the 188-byte packets.
+// The packets that can not be corrected are written as received with their transport_error_indicator bit set
+// (as DVB receivers do) and their indices are returned.
+func DecodeDVBTransportStream(codewords io.Reader, packets io.Writer) ([]int, error) {
+	uncorrectable := []int{}
+	buffer := make([]byte, dvbCodewordBytes)
+
+	for index := 0; ; index++ {
+		_, err := io.ReadFull(codewords, buffer)
+		if err == io.EOF {
+			return uncorrectable, nil // all packets have been decoded
+		}
+		if err == io.ErrUnexpectedEOF {
+			return uncorrectable, errors.New("The stream ends with a partial codeword")
+		}
+		if err != nil {
+			return uncorrectable, err
+		}
+
+		packet, _, err := DecodeDVBPacket(buffer)
+		if err != nil {
+			packet[1] |= dvbTransportError
+			uncorrectable = append(uncorrectable, index)
+		}
+
+		if _, err := packets.Write(packet); err != nil {
+			return uncorrectable, fmt.Errorf("Could not write packet %d: %s", index, err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package reedSolomon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func dvbTestPacket(seed int) []byte {
+	packet := make([]byte, 188)
+	packet[0] = 0x47 // sync byte
+	for i := 1; i < len(packet); i++ {
+		packet[i] = byte(i*seed + 7)
+	}
+	return packet
+}
+
+func TestDVBPacket(t *testing.T) {
+	t.Log("Testing RS(204,188) packet encoding and correction")
+
+	packet := dvbTestPacket(3)
+	codeword, err := EncodeDVBPacket(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codeword) != 204 || !bytes.Equal(codeword[:188], packet) {
+		t.Fatal("The codeword was expected to be the packet followed by 16 parity bytes")
+	}
+
+	// The shortened codeword is a valid RS(255,239) codeword once the 51 zero bytes are prefixed
+	full := make([]int, 255)
+	for i, b := range codeword {
+		full[51+i] = int(b)
+	}
+	for i, s := range dvbField.calculateSyndromes(full, 16) {
+		if s != 0 {
+			t.Errorf("Syndrome %d was expected to be 0, but it was %d instead.", i, s)
+		}
+	}
+
+	// 8 byte errors, including the sync byte and a parity byte
+	for _, i := range []int{0, 17, 50, 99, 120, 187, 190, 203} {
+		codeword[i] ^= 0xA5
+	}
+
+	resp, stats, err := DecodeDVBPacket(codeword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Corrected != 8 {
+		t.Errorf("8 corrections were expected, but there were %d instead.", stats.Corrected)
+	}
+	if !bytes.Equal(resp, packet) {
+		t.Error("Corrected packet does not match the original packet")
+	}
+}
+
+func TestDVBTransportStream(t *testing.T) {
+	t.Log("Testing decoding a transport stream with an uncorrectable packet")
+
+	stream := &bytes.Buffer{}
+	packets := [][]byte{}
+	for p := 0; p < 5; p++ {
+		packets = append(packets, dvbTestPacket(p+1))
+		codeword, _ := EncodeDVBPacket(packets[p])
+		if p == 1 {
+			codeword[5] ^= 1 // correctable
+		}
+		if p == 3 {
+			for i := 0; i < 12; i++ {
+				codeword[10*i] ^= 0xFF // too many errors
+			}
+		}
+		stream.Write(codeword)
+	}
+
+	out := &bytes.Buffer{}
+	uncorrectable, err := DecodeDVBTransportStream(stream, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uncorrectable) != 1 || uncorrectable[0] != 3 {
+		t.Errorf("Only packet 3 was expected to be uncorrectable, but %v were instead.", uncorrectable)
+	}
+	if out.Len() != 5*188 {
+		t.Fatalf("%d bytes of packets were expected, but there were %d instead.", 5*188, out.Len())
+	}
+
+	result := out.Bytes()
+	for p, packet := range packets {
+		if p == 3 {
+			if result[3*188+1]&0x80 == 0 {
+				t.Error("The transport_error_indicator of packet 3 was expected to be set")
+			}
+			continue
+		}
+		if !bytes.Equal(result[p*188:(p+1)*188], packet) {
+			t.Errorf("Packet %d does not match the original packet", p)
+		}
+	}
+
+	_, err = DecodeDVBTransportStream(bytes.NewReader(make([]byte, 300)), &bytes.Buffer{})
+	if err == nil {
+		t.Error("Should have stated that the stream ends with a partial codeword")
+	}
+}
@@ -0,0 +1,82 @@
+package reedSolomon
+
+import (
+	"fmt"
+)
+
+// ==========================================
+//             Exported Methods
+// ==========================================
+
+// Encode takes a message (as an int slice) and returns the Reed-Solomon codeword: the message followed by
+// numberEccSymbols error correcting symbols. The output can be handed directly to Decode with the same numberEccSymbols.
+// The galois field look up tables must be initialized (see InitGaloisFields) before encoding.
+func Encode(msg []int, numberEccSymbols int) ([]int, error) {
+	return defaultField().encode(msg, numberEccSymbols)
+}
+
+// ==========================================
+//             Unexported Methods
+// ==========================================
+
+// Systematic encoding of msg with numberEccSymbols ecc symbols, see Encode
+func (gf *galoisField) encode(msg []int, numberEccSymbols int) ([]int, error) {
+	if len(msg)+numberEccSymbols > gf.order { // can't encode, codeword would be too big
+		return []int{}, fmt.Errorf("Message is too long (%d when max is %d)", len(msg)+numberEccSymbols, gf.order)
+	}
+
+	return gf.encodeMessage(msg, gf.generatorPolynomial(numberEccSymbols)), nil
+}
+
+// Compute the generator polynomial for nsym error correcting symbols.
+// g(x) = (x - alpha^fcr) * (x - alpha^(fcr+1)) * ... * (x - alpha^(fcr+nsym-1))
+// The roots of the generator are the same values the syndromes are evaluated at, so a valid codeword has clean syndromes.
+func (gf *galoisField) generatorPolynomial(nsym int) []int {
+	g := []int{1}
+	for i := 0; i < nsym; i++ {
+		g = gf.polynomialMultiplication(g, []int{1, gf.alphaPower(i + gf.fcr)})
+	}
+	return g
+}
+
+// Compute the error correcting symbols of msg: the remainder of msg(x) * x^nsym divided by the generator polynomial.
+// This is the same extended synthetic division as gfPolynomialDivision, but only the remainder is kept and
+// the quotient is never stored (the remainder is shifted as each message symbol is consumed).
+func (gf *galoisField) calculateEcc(msg, generator []int) []int {
+	nsym := len(generator) - 1
+	ecc := make([]int, nsym)
+
+	for _, m := range msg {
+		coef := m
+		if nsym > 0 {
+			coef ^= ecc[0]
+			copy(ecc, ecc[1:]) // shift the remainder by one degree
+			ecc[nsym-1] = 0
+		}
+
+		if coef != 0 { // log(0) is undefined
+			for j := 1; j < len(generator); j++ { // skip the first coefficient of the (monic) generator
+				ecc[j-1] ^= gf.multiplication(generator[j], coef)
+			}
+		}
+	}
+	return ecc
+}
+
+// Systematic encoding: the message is left untouched and the ecc symbols are appended to it
+func (gf *galoisField) encodeMessage(msg, generator []int) []int {
+	msgOut := make([]int, 0, len(msg)+len(generator)-1)
+	msgOut = append(msgOut, msg...)
+	return append(msgOut, gf.calculateEcc(msg, generator)...)
+}
+
+// The same encoding steps on the GF(256) field initialized by InitGaloisFields
+func generatorPolynomial(nsym int) []int {
+	return defaultField().generatorPolynomial(nsym)
+}
+func calculateEcc(msg, generator []int) []int {
+	return defaultField().calculateEcc(msg, generator)
+}
+func encodeMessage(msg, generator []int) []int {
+	return defaultField().encodeMessage(msg, generator)
+}
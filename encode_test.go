@@ -0,0 +1,103 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+func TestGeneratorPolynomial(t *testing.T) {
+	t.Log("Testing the generator polynomial")
+
+	// The generator must evaluate to 0 at each of its roots alpha^(i+fcr)
+	nsym := 8
+	g := generatorPolynomial(nsym)
+
+	if len(g) != nsym+1 {
+		t.Errorf("Expected generator to be of length %d, but it was %d instead.", nsym+1, len(g))
+	}
+	for i := 0; i < nsym; i++ {
+		if r := gfPolynomialEval(g, gfPower(2, i+fcr)); r != 0 {
+			t.Errorf("Generator evaluated at root %d was expected to be 0, but it was %d instead.", i, r)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Log("Testing encoding a message")
+
+	msg := []int{68, 90, 46, 145, 46, 131, 153, 53, 32, 43}
+
+	expected := []int{68, 90, 46, 145, 46, 131, 153, 53, 32, 43, 239, 193, 240, 155, 85, 215, 63, 202}
+	resp, err := Encode(msg, 8)
+
+	if err != nil {
+		t.Error(err)
+	}
+	if len(resp) != len(expected) {
+		t.Fatalf("Expected codeword to be of length %d, but it was %d instead.", len(expected), len(resp))
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+}
+
+func TestEncodeQRCode(t *testing.T) {
+	t.Log("Testing encoding a QR-Code message (README example)")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	msg := []int{104, 101, 108, 108, 111, 32, 119, 111, 114, 108, 100} // "hello world"
+
+	expected := []int{104, 101, 108, 108, 111, 32, 119, 111, 114, 108, 100, 145, 124, 96, 105, 94, 31, 179, 149, 163}
+	resp, err := Encode(msg, 9)
+
+	if err != nil {
+		t.Error(err)
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Log("Testing that an encoded message with errors decodes")
+
+	msg := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	codeword, err := Encode(msg, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 3 errors (cost 6) and 4 erasures (cost 4)
+	codeword[0] = 99
+	codeword[5] = 99
+	codeword[20] = 99
+	codeword[2] = 0
+	codeword[3] = 0
+	codeword[13] = 0
+	codeword[14] = 0
+
+	correctedMsg, _, err := Decode(codeword, 10, []int{2, 3, 13, 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range correctedMsg {
+		if r != msg[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, msg[i], r)
+		}
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	t.Log("Testing encoding a message that is longer then the max 255")
+
+	_, err := Encode(make([]int, 250), 8)
+
+	if err == nil || err.Error() != "Message is too long (258 when max is 255)" {
+		t.Error("Should have stated that the message was too long")
+	}
+}
@@ -0,0 +1,101 @@
+package reedSolomon
+
+// galoisField holds the look up tables of a GF(2^m) field and the first consecutive root of the codes built on it.
+// The package level functions (Decode, Encode, gfMultiplication...) work on the GF(256) field set up by InitGaloisFields,
+// other fields (for example GF(16) or GF(4096) for Aztec codes) are created with newGaloisField.
+type galoisField struct {
+	order     int   // number of non-zero elements (2^m - 1), this is also the maximum length of a codeword
+	exponents []int // anti-log (exponential) table, doubled so that we don't need to mod order when multiplying
+	logs      []int // log table, log[0] is impossible and thus unused
+	fcr       int   // first consecutive root
+
+	// Full multiplication table of the small fields (see productTableMaxBits), nil for the bigger ones
+	products     []int // products[x<<productShift | y] = x * y
+	inverses     []int // inverses[x] = 1 / x, inverses[0] is unused
+	productShift uint  // number of bits of a symbol
+}
+
+// Biggest fields (in bits per symbol) multiplied with a full table instead of the log and exponent tables.
+// Up to GF(64) the table holds at most 4096 products, and a product is a single look up without zero checks.
+const productTableMaxBits = 6
+
+// newGaloisField precomputes the tables of GF(2^bits) using the provided primitive polynomial
+func newGaloisField(bits, prim, firstConsecutiveRoot int) *galoisField {
+	size := 1 << uint(bits)
+
+	gf := &galoisField{
+		order:     size - 1,
+		exponents: make([]int, 2*(size-1)),
+		logs:      make([]int, size),
+		fcr:       firstConsecutiveRoot,
+	}
+	gf.buildTables(prim)
+	if bits <= productTableMaxBits {
+		gf.buildProductTables(uint(bits))
+	}
+
+	return gf
+}
+
+// defaultField is the GF(256) field whose tables are initialized by InitGaloisFields
+func defaultField() *galoisField {
+	return &galoisField{order: 255, exponents: exponents[:], logs: logs[:], fcr: fcr}
+}
+
+// Compute the logarithm and anti-logarithm tables of the field from the primitive polynomial
+func (gf *galoisField) buildTables(prim int) {
+	size := gf.order + 1
+
+	// For each possible value in the galois field, we will pre-compute the logarithm and anti-logarithm (exponential) of this value
+	x := 1
+	for i := 0; i < gf.order; i++ {
+
+		gf.exponents[i] = x // compute exponents for this value and store it in a table
+		gf.logs[x] = i      // compute log at the same time
+
+		// TODO: if generator=2 use current method (fastest) if not require fast or slow defined in inputs
+		// Slow: Standard carry-less multiplication + modular reduction using an irreducible prime polynomial.
+		// Fast: Russian Peasant Multiplication algorithm
+
+		x <<= 1          // Bitwise multiply by 2 (change 1 by another number y to multiply by a power of 2^y)
+		if x&size != 0 { // similar to x >= size, but a lot faster (0x100 for GF(256))
+			// Rolls over the value from size back to 0 and then up again
+			x ^= prim // subtract the primary polynomial to the current value (instead of size-1, so that we get a unique set made of coprime numbers), this is the core of the tables generation
+		}
+	}
+
+	// Double the size of the anti-log table so that we don't need to mod order later
+	copy(gf.exponents[gf.order:], gf.exponents[:gf.order]) // optimized (vs for loop)
+}
+
+// Compute the full multiplication and inverse tables from the log and exponent tables
+func (gf *galoisField) buildProductTables(bits uint) {
+	size := gf.order + 1
+	products := make([]int, size<<bits)
+	inverses := make([]int, size)
+
+	for x := 1; x < size; x++ {
+		for y := 1; y < size; y++ {
+			products[x<<bits|y] = gf.exponents[gf.logs[x]+gf.logs[y]]
+		}
+		inverses[x] = gf.exponents[gf.order-gf.logs[x]]
+	}
+
+	gf.products = products
+	gf.inverses = inverses
+	gf.productShift = bits
+}
+
+// Switch the primitive element of the field from alpha to alpha^power (power must be coprime with the order).
+// This is needed by codes whose generator polynomial roots are consecutive powers of another primitive element
+// (CCSDS uses alpha^11): the syndromes, error positions and generator all use the new element.
+func (gf *galoisField) useGenerator(power int) {
+	exponents := make([]int, len(gf.exponents))
+	for i := 0; i < gf.order; i++ {
+		exponents[i] = gf.exponents[(i*power)%gf.order]
+		gf.logs[exponents[i]] = i
+	}
+	copy(exponents[gf.order:], exponents[:gf.order])
+
+	gf.exponents = exponents
+}
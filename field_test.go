@@ -0,0 +1,98 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+// The same field multiplied with the log and exponent tables only
+func withoutProductTables(gf *galoisField) *galoisField {
+	logField := *gf
+	logField.products = nil
+	logField.inverses = nil
+	return &logField
+}
+
+func TestProductTables(t *testing.T) {
+	t.Log("Testing the full multiplication tables of GF(16) and GF(64)")
+
+	for _, gf := range []*galoisField{newGaloisField(4, 0x13, 1), newGaloisField(6, 0x43, 1)} {
+		if gf.products == nil {
+			t.Fatalf("GF(%d) was expected to have a multiplication table", gf.order+1)
+		}
+		logField := withoutProductTables(gf)
+
+		for x := 0; x <= gf.order; x++ {
+			for y := 0; y <= gf.order; y++ {
+				if r, expected := gf.multiplication(x, y), logField.multiplication(x, y); r != expected {
+					t.Fatalf("%d * %d was expected to be %d, but it was %d instead.", x, y, expected, r)
+				}
+				if y == 0 {
+					continue
+				}
+				r, _ := gf.division(x, y)
+				if expected, _ := logField.division(x, y); r != expected {
+					t.Fatalf("%d / %d was expected to be %d, but it was %d instead.", x, y, expected, r)
+				}
+			}
+		}
+
+		if _, err := gf.division(1, 0); err == nil {
+			t.Error("Dividing by 0 should fail")
+		}
+	}
+
+	if newGaloisField(8, 0x11D, 0).products != nil {
+		t.Error("GF(256) should use the log and exponent tables")
+	}
+}
+
+// Multiply every pair of symbols of GF(64), with the full table and with the log tables
+func benchmarkFieldMultiplication(b *testing.B, gf *galoisField) {
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		for x := 0; x <= gf.order; x++ {
+			for y := 0; y <= gf.order; y++ {
+				sum ^= gf.multiplication(x, y)
+			}
+		}
+	}
+	if sum == -1 {
+		b.Log(sum) // keep the products
+	}
+}
+
+func BenchmarkMultiplicationProductTable(b *testing.B) {
+	benchmarkFieldMultiplication(b, newGaloisField(6, 0x43, 1))
+}
+
+func BenchmarkMultiplicationLogTables(b *testing.B) {
+	benchmarkFieldMultiplication(b, withoutProductTables(newGaloisField(6, 0x43, 1)))
+}
+
+// Decode a MaxiCode-sized GF(64) codeword with errors, with the full table and with the log tables
+func benchmarkFieldDecode(b *testing.B, gf *galoisField) {
+	msg := make([]int, 40)
+	for i := range msg {
+		msg[i] = i
+	}
+	codeword, _ := gf.encode(msg, 20)
+	received := make([]int, len(codeword))
+
+	for i := 0; i < b.N; i++ {
+		copy(received, codeword)
+		for p := 0; p < 10; p++ {
+			received[p*5] ^= 0x2A
+		}
+		if _, _, err := gf.decode(received, 20, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeProductTable(b *testing.B) {
+	benchmarkFieldDecode(b, newGaloisField(6, 0x43, 1))
+}
+
+func BenchmarkDecodeLogTables(b *testing.B) {
+	benchmarkFieldDecode(b, withoutProductTables(newGaloisField(6, 0x43, 1)))
+}
@@ -6,3 +6,19 @@ func sliceIntReverse(s []int) []int {
 	}
 	return s
 }
+
+func bytesToSymbols(b []byte) []int {
+	s := make([]int, len(b))
+	for i, v := range b {
+		s[i] = int(v)
+	}
+	return s
+}
+
+func symbolsToBytes(s []int) []byte {
+	b := make([]byte, len(s))
+	for i, v := range s {
+		b[i] = byte(v)
+	}
+	return b
+}
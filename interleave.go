@@ -0,0 +1,290 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Interleaver spreads depth codewords symbol by symbol over the channel: symbol i of codeword j is sent at position
+// i*depth + j. A burst of up to depth*t corrupted symbols on the channel therefore only costs t symbols per codeword.
+// The galois field look up tables must be initialized (see InitGaloisFields) before use.
+type Interleaver struct {
+	depth            int // number of interleaved codewords
+	numberEccSymbols int // number of ecc symbols of every codeword
+	generator        []int
+}
+
+// NewInterleaver creates an interleaver of depth codewords, each with numberEccSymbols ecc symbols
+func NewInterleaver(depth, numberEccSymbols int) (*Interleaver, error) {
+	if depth <= 0 {
+		return nil, errors.New("Invalid interleaving depth")
+	}
+	if numberEccSymbols <= 0 || numberEccSymbols >= 255 {
+		return nil, fmt.Errorf("Invalid number of ecc symbols (%d when it must be between 1 and 254)", numberEccSymbols)
+	}
+
+	return &Interleaver{
+		depth:            depth,
+		numberEccSymbols: numberEccSymbols,
+		generator:        generatorPolynomial(numberEccSymbols),
+	}, nil
+}
+
+// Encode splits msg into depth consecutive blocks of equal size, encodes each of them and interleaves the codewords.
+// The length of msg must be a multiple of depth.
+func (il *Interleaver) Encode(msg []int) ([]int, error) {
+	if len(msg)%il.depth != 0 {
+		return []int{}, fmt.Errorf("Message length (%d) must be a multiple of the interleaving depth (%d)", len(msg), il.depth)
+	}
+
+	dataSymbols := len(msg) / il.depth
+	if dataSymbols+il.numberEccSymbols > 255 {
+		return []int{}, fmt.Errorf("Message is too long (%d when max is 255)", dataSymbols+il.numberEccSymbols)
+	}
+
+	codewords := make([][]int, il.depth)
+	for j := range codewords {
+		codewords[j] = encodeMessage(msg[j*dataSymbols:(j+1)*dataSymbols], il.generator)
+	}
+
+	return il.Interleave(codewords)
+}
+
+// Decode deinterleaves msg, decodes every codeword and returns the data symbols in their original order.
+// erasedIndices are positions on the channel (in msg), they are mapped to the codeword and index they belong to.
+// Codewords that can not be corrected are returned as received and the first failure is returned as the error.
+func (il *Interleaver) Decode(msg []int, erasedIndices []int) ([]int, []BlockStats, error) {
+	codewords, codewordErasures, err := il.Deinterleave(msg, erasedIndices)
+	if err != nil {
+		return []int{}, []BlockStats{}, err
+	}
+
+	codewordLength := len(msg) / il.depth
+	if codewordLength <= il.numberEccSymbols || codewordLength > 255 {
+		return []int{}, []BlockStats{}, fmt.Errorf("Invalid codeword length (%d)", codewordLength)
+	}
+
+	msgOut := make([]int, 0, len(msg)-il.depth*il.numberEccSymbols)
+	stats := make([]BlockStats, il.depth)
+
+	for j, codeword := range codewords {
+		var correctedMsg []int
+		correctedMsg, stats[j] = decodeBlock(codeword, il.numberEccSymbols, codewordErasures[j])
+		if stats[j].Err != nil && err == nil {
+			err = fmt.Errorf("Could not decode codeword %d: %s", j, stats[j].Err)
+		}
+		msgOut = append(msgOut, correctedMsg...)
+	}
+
+	return msgOut, stats, err
+}
+
+// Interleave merges depth codewords of the same length symbol by symbol
+func (il *Interleaver) Interleave(codewords [][]int) ([]int, error) {
+	if len(codewords) != il.depth {
+		return []int{}, fmt.Errorf("Wrong number of codewords (%d when %d are expected)", len(codewords), il.depth)
+	}
+
+	length := len(codewords[0])
+	msgOut := make([]int, length*il.depth)
+
+	for j, codeword := range codewords {
+		if len(codeword) != length {
+			return []int{}, errors.New("Codeword lengths do not match")
+		}
+		for i, symbol := range codeword {
+			msgOut[i*il.depth+j] = symbol
+		}
+	}
+
+	return msgOut, nil
+}
+
+// Deinterleave splits msg back into depth codewords, and maps the channel erasedIndices to the indices of each codeword
+func (il *Interleaver) Deinterleave(msg []int, erasedIndices []int) ([][]int, [][]int, error) {
+	if len(msg)%il.depth != 0 {
+		return [][]int{}, [][]int{}, fmt.Errorf("Message length (%d) must be a multiple of the interleaving depth (%d)", len(msg), il.depth)
+	}
+
+	length := len(msg) / il.depth
+	codewords := make([][]int, il.depth)
+	codewordErasures := make([][]int, il.depth)
+
+	for j := range codewords {
+		codewords[j] = make([]int, length)
+		codewordErasures[j] = []int{}
+	}
+	for p, symbol := range msg {
+		codewords[p%il.depth][p/il.depth] = symbol
+	}
+
+	for _, p := range erasedIndices {
+		if p < 0 || p >= len(msg) {
+			return [][]int{}, [][]int{}, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+		codewordErasures[p%il.depth] = append(codewordErasures[p%il.depth], p/il.depth)
+	}
+
+	return codewords, codewordErasures, nil
+}
+
+// interleavedLayout describes how the data and ecc symbols of several blocks are spread over a single stream
+// (used by the barcode profiles, where the blocks don't always have the same length)
+type interleavedLayout struct {
+	dataLengths      []int        // number of data symbols of each block
+	numberEccSymbols int          // number of ecc symbols of every block
+	positions        [][]int      // positions[b][i] is the position in the stream of symbol i of block b (data then ecc)
+	interleavedData  bool         // the data symbols are in stream order (Data Matrix), otherwise block after block (QR Code)
+	field            *galoisField // field of the blocks, nil for the GF(256) field initialized by InitGaloisFields
+}
+
+// Field of the blocks (the default field is looked up on use, so that the tables can be initialized after the layout)
+func (l *interleavedLayout) galoisField() *galoisField {
+	if l.field == nil {
+		return defaultField()
+	}
+	return l.field
+}
+
+// Total number of symbols in the stream
+func (l *interleavedLayout) length() int {
+	total := 0
+	for _, n := range l.dataLengths {
+		total += n + l.numberEccSymbols
+	}
+	return total
+}
+
+// Total number of data symbols in the stream
+func (l *interleavedLayout) dataLength() int {
+	total := 0
+	for _, n := range l.dataLengths {
+		total += n
+	}
+	return total
+}
+
+// Split the data symbols into blocks, encode them and place their symbols in the stream
+func (l *interleavedLayout) encode(data []int) ([]int, error) {
+	if len(data) != l.dataLength() {
+		return []int{}, fmt.Errorf("Wrong number of data symbols (%d when %d are expected)", len(data), l.dataLength())
+	}
+
+	gf := l.galoisField()
+	generator := gf.generatorPolynomial(l.numberEccSymbols)
+	msgOut := make([]int, l.length())
+
+	start := 0
+	for b, n := range l.dataLengths {
+		blockData := data[start : start+n]
+		if l.interleavedData {
+			blockData = make([]int, n)
+			for i := range blockData {
+				blockData[i] = data[l.positions[b][i]]
+			}
+		}
+
+		for i, symbol := range gf.encodeMessage(blockData, generator) {
+			msgOut[l.positions[b][i]] = symbol
+		}
+		start += n
+	}
+
+	return msgOut, nil
+}
+
+// Gather the symbols of every block from the stream, decode them and return the data symbols of all blocks.
+// erasedIndices are positions in the stream, they are mapped to the block and index they belong to.
+// Blocks that can not be corrected are returned as received and the first failure is returned as the error.
+func (l *interleavedLayout) decode(msg []int, erasedIndices []int) ([]int, []BlockStats, error) {
+	if len(msg) != l.length() {
+		return []int{}, []BlockStats{}, fmt.Errorf("Wrong number of symbols (%d when %d are expected)", len(msg), l.length())
+	}
+
+	// Map the stream positions back to the blocks
+	block := make([]int, len(msg))
+	index := make([]int, len(msg))
+	for b, positions := range l.positions {
+		for i, p := range positions {
+			block[p] = b
+			index[p] = i
+		}
+	}
+
+	blockErasures := make([][]int, len(l.dataLengths))
+	for b := range blockErasures {
+		blockErasures[b] = []int{}
+	}
+	for _, p := range erasedIndices {
+		if p < 0 || p >= len(msg) {
+			return []int{}, []BlockStats{}, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+		blockErasures[block[p]] = append(blockErasures[block[p]], index[p])
+	}
+
+	gf := l.galoisField()
+	msgOut := make([]int, l.dataLength())
+	stats := make([]BlockStats, len(l.dataLengths))
+	var err error
+
+	start := 0
+	for b, positions := range l.positions {
+		codeword := make([]int, len(positions))
+		for i, p := range positions {
+			codeword[i] = msg[p]
+		}
+
+		var correctedMsg []int
+		correctedMsg, stats[b] = gf.decodeBlock(codeword, l.numberEccSymbols, blockErasures[b])
+		if stats[b].Err != nil && err == nil {
+			err = fmt.Errorf("Could not decode block %d: %s", b, stats[b].Err)
+		}
+
+		if l.interleavedData {
+			for i, symbol := range correctedMsg {
+				msgOut[positions[i]] = symbol
+			}
+		} else {
+			copy(msgOut[start:], correctedMsg)
+		}
+		start += len(correctedMsg)
+	}
+
+	return msgOut, stats, err
+}
+
+// Layout where the blocks are interleaved symbol by symbol, first all the data symbols and then all the ecc symbols.
+// Blocks with fewer data symbols are skipped once they run out (QR codes place the shorter blocks first).
+func columnInterleavedLayout(dataLengths []int, numberEccSymbols int) *interleavedLayout {
+	l := &interleavedLayout{
+		dataLengths:      dataLengths,
+		numberEccSymbols: numberEccSymbols,
+		positions:        make([][]int, len(dataLengths)),
+	}
+
+	maxData := 0
+	for b, n := range dataLengths {
+		l.positions[b] = make([]int, n+numberEccSymbols)
+		if n > maxData {
+			maxData = n
+		}
+	}
+
+	p := 0
+	for i := 0; i < maxData; i++ {
+		for b, n := range dataLengths {
+			if i < n {
+				l.positions[b][i] = p
+				p++
+			}
+		}
+	}
+	for i := 0; i < numberEccSymbols; i++ {
+		for b, n := range dataLengths {
+			l.positions[b][n+i] = p
+			p++
+		}
+	}
+
+	return l
+}
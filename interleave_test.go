@@ -0,0 +1,124 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+func TestInterleave(t *testing.T) {
+	t.Log("Testing interleaving and deinterleaving codewords")
+
+	il, _ := NewInterleaver(3, 2)
+	codewords := [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}
+
+	expected := []int{1, 5, 9, 2, 6, 10, 3, 7, 11, 4, 8, 12}
+	resp, err := il.Interleave(codewords)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+
+	deinterleaved, erasures, err := il.Deinterleave(resp, []int{4, 5, 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, codeword := range deinterleaved {
+		for i, r := range codeword {
+			if r != codewords[j][i] {
+				t.Errorf("Codeword %d at index %d was expected to be %d, but it was %d instead.", j, i, codewords[j][i], r)
+			}
+		}
+	}
+
+	// channel position 4 is codeword 1 index 1, 5 is codeword 2 index 1 and 11 is codeword 2 index 3
+	expectedErasures := [][]int{{}, {1}, {1, 3}}
+	for j := range erasures {
+		if len(erasures[j]) != len(expectedErasures[j]) {
+			t.Fatalf("Codeword %d was expected to have erasures %d, but it had %d instead.", j, expectedErasures[j], erasures[j])
+		}
+		for i, r := range erasures[j] {
+			if r != expectedErasures[j][i] {
+				t.Errorf("Erasure %d of codeword %d was expected to be %d, but it was %d instead.", i, j, expectedErasures[j][i], r)
+			}
+		}
+	}
+}
+
+func TestInterleaverBurstErrors(t *testing.T) {
+	t.Log("Testing correcting a burst of errors spread over interleaved codewords")
+
+	il, _ := NewInterleaver(4, 4)
+	msg := makeTestPayload(40)
+
+	encoded, err := il.Encode(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A burst of 8 errors is 2 errors per codeword (the most a single codeword can correct)
+	for p := 10; p < 18; p++ {
+		encoded[p] ^= 0xFF
+	}
+
+	decoded, stats, err := il.Decode(encoded, []int{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range decoded {
+		if r != msg[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, msg[i], r)
+		}
+	}
+	for j, s := range stats {
+		if s.Corrected != 2 {
+			t.Errorf("Codeword %d was expected to have 2 corrections, but it had %d instead.", j, s.Corrected)
+		}
+	}
+}
+
+func TestInterleaverBurstErasures(t *testing.T) {
+	t.Log("Testing correcting a burst of erasures spread over interleaved codewords")
+
+	il, _ := NewInterleaver(4, 4)
+	msg := makeTestPayload(40)
+	encoded, _ := il.Encode(msg)
+
+	// A burst of 16 erasures is 4 erasures per codeword
+	erasures := []int{}
+	for p := 20; p < 36; p++ {
+		encoded[p] = 0
+		erasures = append(erasures, p)
+	}
+
+	decoded, stats, err := il.Decode(encoded, erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range decoded {
+		if r != msg[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, msg[i], r)
+		}
+	}
+	for j, s := range stats {
+		if s.Erasures != 4 {
+			t.Errorf("Codeword %d was expected to have 4 erasures, but it had %d instead.", j, s.Erasures)
+		}
+	}
+}
+
+func TestInterleaverInvalidLength(t *testing.T) {
+	t.Log("Testing interleaving messages of invalid length")
+
+	il, _ := NewInterleaver(4, 4)
+
+	if _, err := il.Encode(make([]int, 10)); err == nil {
+		t.Error("Should have stated that the message length is not a multiple of the depth")
+	}
+	if _, _, err := il.Decode(make([]int, 12), []int{}); err == nil {
+		t.Error("Should have stated that the codewords are too short")
+	}
+}
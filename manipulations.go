@@ -16,55 +16,88 @@ func gfSubtraction(x, y int) int {
 	return gfAddition(x, y)
 }
 
-// Use the exponents table to lookup multiplication value
-func gfMultiplication(x, y int) int {
+// Use the exponents table to lookup multiplication value (or the full table of the small fields)
+func (gf *galoisField) multiplication(x, y int) int {
+	if gf.products != nil {
+		return gf.products[x<<gf.productShift|y]
+	}
 	if x == 0 || y == 0 {
 		return 0
 	}
-	return exponents[logs[x]+logs[y]]
+	return gf.exponents[gf.logs[x]+gf.logs[y]]
 }
 
-func gfDivision(x, y int) (int, error) {
+func (gf *galoisField) division(x, y int) (int, error) {
 	if y == 0 {
 		return -1, errors.New("Zero Division Error")
 	}
+	if gf.products != nil {
+		return gf.products[x<<gf.productShift|gf.inverses[y]], nil
+	}
 	if x == 0 {
 		return 0, nil
 	}
-	return exponents[(logs[x]+255-logs[y])%255], nil
+	return gf.exponents[(gf.logs[x]+gf.order-gf.logs[y])%gf.order], nil
 }
 
-func gfPower(x, power int) int {
+func (gf *galoisField) power(x, power int) int {
 
-	index := (logs[x] * power) % 255
+	index := (gf.logs[x] * power) % gf.order
 
 	// If the index is positive get it
 	if index >= 0 {
-		return exponents[index]
+		return gf.exponents[index]
 	}
 	// If the index is negative simulate a rollover in the LUT
-	return exponents[len(exponents)+index]
+	return gf.exponents[len(gf.exponents)+index]
 }
 
+// alpha^power, the primitive element of the field (2 unless the field uses another generator, see useGenerator)
+func (gf *galoisField) alphaPower(power int) int {
+	power %= gf.order
+	if power < 0 {
+		power += gf.order
+	}
+	return gf.exponents[power]
+}
+
+func (gf *galoisField) inverse(x int) int {
+	return gf.exponents[gf.order-gf.logs[x]] // gf.inverse(x) == gf.division(1, x)
+}
+
+// The same operations on the GF(256) field initialized by InitGaloisFields
+func gfMultiplication(x, y int) int {
+	return defaultField().multiplication(x, y)
+}
+func gfDivision(x, y int) (int, error) {
+	return defaultField().division(x, y)
+}
+func gfPower(x, power int) int {
+	return defaultField().power(x, power)
+}
 func gfInverse(x int) int {
-	return exponents[255-logs[x]] // gfInverse(x) == gfDivision(1, x)
+	return defaultField().inverse(x)
 }
 
 // ***************************
 // Polynomial Manipulations
 // ***************************
 
-func gfPolynomialScale(p []int, x int) []int {
+func (gf *galoisField) polynomialScale(p []int, x int) []int {
 	// TODO: manipulate and return p?
 	r := make([]int, len(p)) // make a destination array
 
+	if gf.hasMulTables() && len(p) >= mulTableMinLength {
+		mulSliceInt(gf.newMulTable(x), p, r)
+		return r
+	}
 	for i := 0; i < len(p); i++ {
-		r[i] = gfMultiplication(p[i], x)
+		r[i] = gf.multiplication(p[i], x)
 	}
 	return r
 }
 
-func gfPolynomialDivision(dividend, divisor []int) ([]int, []int) {
+func (gf *galoisField) polynomialDivision(dividend, divisor []int) ([]int, []int) {
 	// Fast polynomial division by using Extended Synthetic Division and optimized for GF(2^p) computations
 	// (doesn't work with standard polynomials outside of this galois field, see the Wikipedia article for generic algorithm).
 	// CAUTION: this function expects polynomials to follow the opposite convention at decoding:
@@ -84,7 +117,7 @@ func gfPolynomialDivision(dividend, divisor []int) ([]int, []int) {
 			for j := 1; j < len(divisor); j++ { // in synthetic division, we always skip the first coefficient of the divisior,
 				// because it's only used to normalize the dividend coefficient
 				if divisor[j] != 0 { // log(0) is undefined
-					msgOut[i+j] ^= gfMultiplication(divisor[j], coef) // equivalent to the more mathematically correct
+					msgOut[i+j] ^= gf.multiplication(divisor[j], coef) // equivalent to the more mathematically correct
 					// (but xoring directly is faster): msg_out[i + j] += -divisor[j] * coef
 				}
 			}
@@ -98,26 +131,39 @@ func gfPolynomialDivision(dividend, divisor []int) ([]int, []int) {
 	return msgOut[:separator], msgOut[separator:] // return quotient, remainder.
 }
 
-func gfPolynomialEval(poly []int, x int) int {
+func (gf *galoisField) polynomialEval(poly []int, x int) int {
 	// Evaluates a polynomial in GF(2^p) given the value for x .This is based on Horner's scheme for maximum efficiency.
 	y := poly[0]
 
+	if gf.hasMulTables() && len(poly) >= mulTableMinLength {
+		var t mulTable // every step multiplies by the same x
+		gf.fillMulTable(&t, x)
+		for i := 1; i < len(poly); i++ {
+			y = int(t.low[y&0x0F]^t.high[y>>4]) ^ poly[i]
+		}
+		return y
+	}
+
 	for i := 1; i < len(poly); i++ {
-		y = gfMultiplication(y, x) ^ poly[i]
+		y = gf.multiplication(y, x) ^ poly[i]
 	}
 
 	return y
 }
 
-func gfPolynomialMultiplication(p, q []int) []int {
+func (gf *galoisField) polynomialMultiplication(p, q []int) []int {
 	// Multiply two polynomials, inside Galois Field
 	// Pre-allocate the result array
 	r := make([]int, len(p)+len(q)-1)
 	// Compute the polynomial multiplication (just like the outer product of two vectors,
 	// we multiply each coefficients of p with all coefficients of q)
 	for j := 0; j < len(q); j++ {
+		if gf.hasMulTables() && len(p) >= mulTableMinLength {
+			mulAddSliceInt(gf.newMulTable(q[j]), p, r[j:]) // the same loop as below, with the nibble tables of q[j]
+			continue
+		}
 		for i := 0; i < len(p); i++ {
-			r[i+j] ^= gfMultiplication(p[i], q[j]) // equivalent to: r[i + j] = gfAddition(r[i+j], gfMultiplication(p[i], q[j]))
+			r[i+j] ^= gf.multiplication(p[i], q[j]) // equivalent to: r[i + j] = gfAddition(r[i+j], gf.multiplication(p[i], q[j]))
 		}
 	}
 	// -- you can see it's your usual polynomial multiplication
@@ -142,3 +188,17 @@ func gfPolynomialAddition(p, q []int) []int {
 	}
 	return r
 }
+
+// The same operations on the GF(256) field initialized by InitGaloisFields
+func gfPolynomialScale(p []int, x int) []int {
+	return defaultField().polynomialScale(p, x)
+}
+func gfPolynomialDivision(dividend, divisor []int) ([]int, []int) {
+	return defaultField().polynomialDivision(dividend, divisor)
+}
+func gfPolynomialEval(poly []int, x int) int {
+	return defaultField().polynomialEval(poly, x)
+}
+func gfPolynomialMultiplication(p, q []int) []int {
+	return defaultField().polynomialMultiplication(p, q)
+}
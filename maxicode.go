@@ -0,0 +1,160 @@
+package reedSolomon
+
+import (
+	"fmt"
+)
+
+// MaxiCode (ISO/IEC 16023) symbols hold 144 codewords of 6 bits, protected over GF(64) (prim 0x43, fcr 1),
+// the same field as the smallest Aztec codes.
+// The primary message (the first 20 codewords) is a single block of 10 data and 10 ecc codewords.
+// The secondary message is split into 2 interleaved blocks: the even and the odd codewords.
+var maxiCodeField = aztecField6
+
+const (
+	maxiCodeCodewords        = 144
+	maxiCodePrimaryCodewords = 20
+	maxiCodePrimaryData      = 10
+	maxiCodePrimaryEcc       = 10
+	maxiCodeSecEcc           = 20 // ecc codewords of each secondary block, Standard Error Correction (modes 2, 3, 4 and 6)
+	maxiCodeEecEcc           = 28 // ecc codewords of each secondary block, Enhanced Error Correction (mode 5)
+)
+
+// MaxiCodeProfile describes the error correction of a MaxiCode mode (2 to 6).
+// It doesn't depend on InitGaloisFields.
+type MaxiCodeProfile struct {
+	Mode      int
+	secondary *interleavedLayout
+}
+
+// NewMaxiCodeProfile creates the error correction profile of a MaxiCode mode: mode 5 uses Enhanced Error Correction
+// for the secondary message, the others Standard Error Correction.
+func NewMaxiCodeProfile(mode int) (*MaxiCodeProfile, error) {
+	if mode < 2 || mode > 6 {
+		return nil, fmt.Errorf("Invalid MaxiCode mode %d (must be between 2 and 6)", mode)
+	}
+
+	numberEccSymbols := maxiCodeSecEcc
+	if mode == 5 {
+		numberEccSymbols = maxiCodeEecEcc
+	}
+
+	// Both secondary blocks have the same length, the data codewords are interleaved first and then the ecc codewords
+	dataLength := (maxiCodeCodewords-maxiCodePrimaryCodewords)/2 - numberEccSymbols
+	l := columnInterleavedLayout([]int{dataLength, dataLength}, numberEccSymbols)
+	l.interleavedData = true
+	l.field = maxiCodeField
+
+	return &MaxiCodeProfile{Mode: mode, secondary: l}, nil
+}
+
+// DataCodewords returns the number of data codewords of the symbol (primary and secondary messages)
+func (m *MaxiCodeProfile) DataCodewords() int {
+	return maxiCodePrimaryData + m.secondary.dataLength()
+}
+
+// Encode computes the ecc codewords of the primary message (the first 10 data codewords) and of both secondary blocks,
+// and returns the 144 codewords in the order they are placed in the symbol.
+func (m *MaxiCodeProfile) Encode(data []int) ([]int, error) {
+	if len(data) != m.DataCodewords() {
+		return []int{}, fmt.Errorf("Wrong number of data codewords (%d when %d are expected)", len(data), m.DataCodewords())
+	}
+	for i, c := range data {
+		if c < 0 || c > maxiCodeField.order {
+			return []int{}, fmt.Errorf("Codeword %d does not fit in 6 bits (%d)", i, c)
+		}
+	}
+
+	primary := maxiCodeField.encodeMessage(data[:maxiCodePrimaryData], maxiCodeField.generatorPolynomial(maxiCodePrimaryEcc))
+	secondary, err := m.secondary.encode(data[maxiCodePrimaryData:])
+	if err != nil {
+		return []int{}, err
+	}
+
+	return append(primary, secondary...), nil
+}
+
+// Decode corrects the primary message and both secondary blocks and returns the data codewords.
+// erasedIndices are positions in the 144 codewords. The statistics of the primary message come first, then the even
+// and the odd secondary blocks. Blocks that can not be corrected are returned as received and the first failure is
+// returned as the error.
+func (m *MaxiCodeProfile) Decode(codewords []int, erasedIndices []int) ([]int, []BlockStats, error) {
+	if err := maxiCodeCheckCodewords(codewords); err != nil {
+		return []int{}, []BlockStats{}, err
+	}
+
+	primaryErasures, secondaryErasures, err := maxiCodeSplitErasures(erasedIndices)
+	if err != nil {
+		return []int{}, []BlockStats{}, err
+	}
+
+	primary, primaryStats := maxiCodeField.decodeBlock(codewords[:maxiCodePrimaryCodewords], maxiCodePrimaryEcc, primaryErasures)
+	if primaryStats.Err != nil {
+		err = fmt.Errorf("Could not decode the primary message: %s", primaryStats.Err)
+	}
+
+	secondary, secondaryStats, secondaryErr := m.secondary.decode(codewords[maxiCodePrimaryCodewords:], secondaryErasures)
+	if secondaryErr != nil && err == nil {
+		err = secondaryErr
+	}
+
+	return append(primary, secondary...), append([]BlockStats{primaryStats}, secondaryStats...), err
+}
+
+// DecodeMaxiCode corrects the primary message, reads the mode from its first codeword and then corrects the secondary
+// message with the error correction of that mode. It returns the mode along with the data codewords and statistics of Decode.
+func DecodeMaxiCode(codewords []int, erasedIndices []int) (int, []int, []BlockStats, error) {
+	if err := maxiCodeCheckCodewords(codewords); err != nil {
+		return 0, []int{}, []BlockStats{}, err
+	}
+
+	primaryErasures, _, err := maxiCodeSplitErasures(erasedIndices)
+	if err != nil {
+		return 0, []int{}, []BlockStats{}, err
+	}
+
+	primary, stats := maxiCodeField.decodeBlock(codewords[:maxiCodePrimaryCodewords], maxiCodePrimaryEcc, primaryErasures)
+	if stats.Err != nil {
+		return 0, []int{}, []BlockStats{stats}, fmt.Errorf("Could not decode the primary message: %s", stats.Err)
+	}
+
+	mode := primary[0] & 0x0F // the mode is in the 4 lowest bits of the first codeword
+	m, err := NewMaxiCodeProfile(mode)
+	if err != nil {
+		return mode, []int{}, []BlockStats{stats}, err
+	}
+
+	data, blockStats, err := m.Decode(codewords, erasedIndices)
+	return mode, data, blockStats, err
+}
+
+// Check that the received codewords fill a symbol and fit in 6 bits
+func maxiCodeCheckCodewords(codewords []int) error {
+	if len(codewords) != maxiCodeCodewords {
+		return fmt.Errorf("Wrong number of codewords (%d when %d are expected)", len(codewords), maxiCodeCodewords)
+	}
+	for i, c := range codewords {
+		if c < 0 || c > maxiCodeField.order {
+			return fmt.Errorf("Codeword %d does not fit in 6 bits (%d)", i, c)
+		}
+	}
+	return nil
+}
+
+// Split the erasures between the primary message and the secondary message (relative to its first codeword)
+func maxiCodeSplitErasures(erasedIndices []int) ([]int, []int, error) {
+	primaryErasures := []int{}
+	secondaryErasures := []int{}
+
+	for _, p := range erasedIndices {
+		switch {
+		case p < 0 || p >= maxiCodeCodewords:
+			return []int{}, []int{}, fmt.Errorf("Erasure position %d is out of range", p)
+		case p < maxiCodePrimaryCodewords:
+			primaryErasures = append(primaryErasures, p)
+		default:
+			secondaryErasures = append(secondaryErasures, p-maxiCodePrimaryCodewords)
+		}
+	}
+
+	return primaryErasures, secondaryErasures, nil
+}
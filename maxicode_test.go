@@ -0,0 +1,149 @@
+package reedSolomon
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Data codewords of a MaxiCode symbol whose first codeword holds the mode
+func maxiCodeTestData(mode, length int) []int {
+	data := make([]int, length)
+	for i := range data {
+		data[i] = (i*13 + 5) % 64
+	}
+	data[0] = 0x30 | mode
+	return data
+}
+
+func TestMaxiCodeProfile(t *testing.T) {
+	t.Log("Testing MaxiCode data codewords of SEC and EEC modes")
+
+	tests := []struct {
+		mode int
+		data int
+	}{
+		{2, 94},
+		{4, 94},
+		{5, 78},
+		{6, 94},
+	}
+
+	for _, test := range tests {
+		m, err := NewMaxiCodeProfile(test.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.DataCodewords() != test.data {
+			t.Errorf("Mode %d was expected to have %d data codewords, but it had %d instead.", test.mode, test.data, m.DataCodewords())
+		}
+	}
+
+	if _, err := NewMaxiCodeProfile(7); err == nil {
+		t.Error("Should have stated that the mode is invalid")
+	}
+}
+
+func TestMaxiCodeDecode(t *testing.T) {
+	t.Log("Testing MaxiCode primary and secondary message correction")
+
+	for _, mode := range []int{4, 5} {
+		m, _ := NewMaxiCodeProfile(mode)
+		data := maxiCodeTestData(mode, m.DataCodewords())
+
+		codewords, err := m.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(codewords) != 144 {
+			t.Fatalf("144 codewords were expected, but there were %d instead.", len(codewords))
+		}
+
+		// 5 errors in the primary message, and as many errors as each secondary block can correct
+		nsym := (144-20)/2 - (m.DataCodewords()-10)/2
+		for i := 0; i < 5; i++ {
+			codewords[2*i] ^= 0x15
+		}
+		for i := 0; i < nsym/2; i++ {
+			codewords[20+4*i] ^= 0x2A   // even block
+			codewords[20+4*i+1] ^= 0x01 // odd block
+		}
+
+		decodedMode, resp, stats, err := DecodeMaxiCode(codewords, []int{})
+		if err != nil {
+			t.Fatalf("Could not decode mode %d: %s", mode, err)
+		}
+		if decodedMode != mode {
+			t.Errorf("Mode %d was expected, but it was %d instead.", mode, decodedMode)
+		}
+		if len(stats) != 3 || stats[0].Corrected != 5 || stats[1].Corrected != nsym/2 || stats[2].Corrected != nsym/2 {
+			t.Errorf("The blocks were expected to have 5, %d and %d corrections, but they had %v instead.", nsym/2, nsym/2, stats)
+		}
+		for i, r := range resp {
+			if r != data[i] {
+				t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], r)
+			}
+		}
+	}
+}
+
+func TestMaxiCodeSecondaryInterleaving(t *testing.T) {
+	t.Log("Testing that the secondary blocks are the even and odd codewords")
+
+	m, _ := NewMaxiCodeProfile(4)
+	data := maxiCodeTestData(4, m.DataCodewords())
+	codewords, _ := m.Encode(data)
+
+	// 11 errors on the even codewords is too many for the even block, the odd block is untouched
+	for i := 0; i < 11; i++ {
+		codewords[20+2*i] ^= 0x3F
+	}
+
+	resp, stats, err := m.Decode(codewords, []int{})
+	if err == nil {
+		t.Error("Should have stated that the even block could not be corrected")
+	}
+	if stats[0].Err != nil || stats[1].Err == nil || stats[2].Err != nil {
+		t.Errorf("Only the even block was expected to fail, but the errors were %v instead.", stats)
+	}
+
+	// The same errors are corrected once their positions are known
+	erasures := []int{}
+	for i := 0; i < 11; i++ {
+		erasures = append(erasures, 20+2*i)
+	}
+	resp, _, err = m.Decode(codewords, erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resp {
+		if r != data[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], r)
+		}
+	}
+}
+
+func TestMaxiCodeDecodeInvalidCodewords(t *testing.T) {
+	t.Log("Testing MaxiCode decoding with codewords that do not fit in 6 bits")
+
+	m, _ := NewMaxiCodeProfile(4)
+	codewords, _ := m.Encode(maxiCodeTestData(4, m.DataCodewords()))
+
+	// In the primary message and in the secondary message
+	for _, p := range []int{5, 100} {
+		received := append([]int{}, codewords...)
+		received[p] = 64
+		expected := fmt.Sprintf("Codeword %d does not fit in 6 bits (64)", p)
+
+		if _, _, err := m.Decode(received, []int{}); err == nil || err.Error() != expected {
+			t.Errorf("Should have stated that codeword %d does not fit in 6 bits", p)
+		}
+		if _, _, _, err := DecodeMaxiCode(received, []int{}); err == nil || err.Error() != expected {
+			t.Errorf("Should have stated that codeword %d does not fit in 6 bits", p)
+		}
+	}
+
+	codewords[7] = -1
+	if _, _, err := m.Decode(codewords, []int{}); err == nil || err.Error() != "Codeword 7 does not fit in 6 bits (-1)" {
+		t.Error("Should have stated that codeword 7 does not fit in 6 bits")
+	}
+}
@@ -0,0 +1,87 @@
+package reedSolomon
+
+// ***************************
+// Bulk Manipulations
+// ***************************
+
+// Shortest slice worth computing the nibble tables for (building them costs 32 multiplications)
+const mulTableMinLength = 32
+
+// mulTable holds the products of a constant c with every nibble, for fields of at most 8 bits (GF(256) and smaller).
+// Multiplying by a constant is linear over GF(2), so c*x = c*(x & 0x0F) ^ c*(x & 0xF0) = low[x & 0x0F] ^ high[x >> 4]:
+// two lookups and a XOR per symbol, without the log/exponent lookups and the zero checks of multiplication.
+type mulTable struct {
+	low    [16]byte // low[n] = c * n
+	high   [16]byte // high[n] = c * (n << 4)
+	matrix uint64   // the same linear map as an 8x8 bit matrix, for the GFNI affine instruction (see affineMatrix)
+}
+
+// Compute the nibble tables of the constant c (the field must have at most 256 elements)
+func (gf *galoisField) newMulTable(c int) *mulTable {
+	t := &mulTable{}
+	gf.fillMulTable(t, c)
+	return t
+}
+
+// Compute the nibble tables of the constant c into t, so that a table on the stack can be reused without allocating
+func (gf *galoisField) fillMulTable(t *mulTable, c int) {
+	for n := 0; n < 16; n++ {
+		t.low[n] = byte(gf.multiplication(c, n))
+		t.high[n] = 0
+		if n<<4 <= gf.order {
+			t.high[n] = byte(gf.multiplication(c, n<<4))
+		}
+	}
+	t.matrix = t.affineMatrix()
+}
+
+// Bit matrix of the multiplication by c in the layout of VGF2P8AFFINEQB: bit i of c*x is the parity of x AND the
+// row stored in byte 7-i of the matrix, so bit j of that row is set when bit i of c*(1 << j) is set.
+// Any field polynomial can be used this way (the GF2P8MULB instruction only knows the AES polynomial 0x11B).
+func (t *mulTable) affineMatrix() uint64 {
+	var matrix uint64
+	for j := uint(0); j < 8; j++ {
+		column := t.low[1<<j&0x0F] ^ t.high[1<<j>>4] // c * (1 << j)
+		for i := uint(0); i < 8; i++ {
+			if column&(1<<i) != 0 {
+				matrix |= 1 << (8*(7-i) + j)
+			}
+		}
+	}
+	return matrix
+}
+
+// Check if the symbols of the field fit in a byte, and can therefore be multiplied with nibble tables
+func (gf *galoisField) hasMulTables() bool {
+	return gf.order <= 255
+}
+
+// out[i] = c * in[i], out must be at least as long as in (pure Go version of mulSlice)
+func mulSliceGeneric(t *mulTable, in, out []byte) {
+	out = out[:len(in)]
+	for i, x := range in {
+		out[i] = t.low[x&0x0F] ^ t.high[x>>4]
+	}
+}
+
+// out[i] ^= c * in[i], out must be at least as long as in (pure Go version of mulAddSlice)
+func mulAddSliceGeneric(t *mulTable, in, out []byte) {
+	out = out[:len(in)]
+	for i, x := range in {
+		out[i] ^= t.low[x&0x0F] ^ t.high[x>>4]
+	}
+}
+
+// The same operations on int symbols (used by the polynomial manipulations)
+func mulSliceInt(t *mulTable, in, out []int) {
+	out = out[:len(in)]
+	for i, x := range in {
+		out[i] = int(t.low[x&0x0F] ^ t.high[x>>4])
+	}
+}
+func mulAddSliceInt(t *mulTable, in, out []int) {
+	out = out[:len(in)]
+	for i, x := range in {
+		out[i] ^= int(t.low[x&0x0F] ^ t.high[x>>4])
+	}
+}
@@ -0,0 +1,122 @@
+package reedSolomon
+
+// The nibble tables are exactly what PSHUFB needs: it looks up 16 (SSSE3) or 32 (AVX2) bytes at once in a 16 byte table,
+// so the assembly kernels multiply a whole register of symbols with 2 lookups. The tail of the slices that doesn't fill
+// a register is done by the pure Go version.
+//
+// With GFNI the multiplication by a constant is a single VGF2P8AFFINEQB of the bit matrix of the constant,
+// on 64 bytes (AVX-512) or 32 bytes (AVX2) at once.
+var (
+	useSSSE3      = false
+	useAVX2       = false
+	useGFNIAVX2   = false
+	useGFNIAVX512 = false
+)
+
+func init() {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 1 {
+		return
+	}
+
+	_, _, ecx1, _ := cpuid(1, 0)
+	useSSSE3 = ecx1&(1<<9) != 0
+
+	// AVX2 also needs the OS to save the YMM registers (OSXSAVE, and XCR0 bits 1 and 2)
+	osAVX := ecx1&(1<<27) != 0 && ecx1&(1<<28) != 0
+	if osAVX {
+		xcr0, _ := xgetbv()
+		osAVX = xcr0&6 == 6
+	}
+	if osAVX && maxLeaf >= 7 {
+		_, ebx7, ecx7, _ := cpuid(7, 0)
+		useAVX2 = ebx7&(1<<5) != 0
+		gfni := ecx7&(1<<8) != 0
+		useGFNIAVX2 = useAVX2 && gfni
+
+		// AVX-512 also needs the OS to save the opmask and ZMM registers (XCR0 bits 5 to 7)
+		xcr0, _ := xgetbv()
+		osAVX512 := xcr0&0xE0 == 0xE0
+		useGFNIAVX512 = osAVX512 && gfni && ebx7&(1<<16) != 0 && ebx7&(1<<30) != 0 // AVX512F and AVX512BW
+	}
+}
+
+// Implemented in mulslice_amd64.s
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+func xgetbv() (eax, edx uint32)
+
+// Multiply the first len(in)/16*16 (SSSE3) or len(in)/32*32 (AVX2) symbols, out must be at least as long as in.
+// Implemented in mulslice_amd64.s
+//
+//go:noescape
+func mulSliceSSSE3(t *mulTable, in, out []byte)
+
+//go:noescape
+func mulAddSliceSSSE3(t *mulTable, in, out []byte)
+
+//go:noescape
+func mulSliceAVX2(t *mulTable, in, out []byte)
+
+//go:noescape
+func mulAddSliceAVX2(t *mulTable, in, out []byte)
+
+// Multiply the first len(in)/32*32 (AVX2) or len(in)/64*64 (AVX-512) symbols with GFNI, out must be at least as long as in.
+// Implemented in mulslice_amd64.s
+//
+//go:noescape
+func mulSliceGFNIAVX2(t *mulTable, in, out []byte)
+
+//go:noescape
+func mulAddSliceGFNIAVX2(t *mulTable, in, out []byte)
+
+//go:noescape
+func mulSliceGFNIAVX512(t *mulTable, in, out []byte)
+
+//go:noescape
+func mulAddSliceGFNIAVX512(t *mulTable, in, out []byte)
+
+// out[i] = c * in[i], out must be at least as long as in
+func mulSlice(t *mulTable, in, out []byte) {
+	out = out[:len(in)]
+	done := 0
+
+	switch {
+	case useGFNIAVX512 && len(in) >= 64:
+		mulSliceGFNIAVX512(t, in, out)
+		done = len(in) &^ 63
+	case useGFNIAVX2 && len(in) >= 32:
+		mulSliceGFNIAVX2(t, in, out)
+		done = len(in) &^ 31
+	case useAVX2 && len(in) >= 32:
+		mulSliceAVX2(t, in, out)
+		done = len(in) &^ 31
+	case useSSSE3 && len(in) >= 16:
+		mulSliceSSSE3(t, in, out)
+		done = len(in) &^ 15
+	}
+
+	mulSliceGeneric(t, in[done:], out[done:])
+}
+
+// out[i] ^= c * in[i], out must be at least as long as in
+func mulAddSlice(t *mulTable, in, out []byte) {
+	out = out[:len(in)]
+	done := 0
+
+	switch {
+	case useGFNIAVX512 && len(in) >= 64:
+		mulAddSliceGFNIAVX512(t, in, out)
+		done = len(in) &^ 63
+	case useGFNIAVX2 && len(in) >= 32:
+		mulAddSliceGFNIAVX2(t, in, out)
+		done = len(in) &^ 31
+	case useAVX2 && len(in) >= 32:
+		mulAddSliceAVX2(t, in, out)
+		done = len(in) &^ 31
+	case useSSSE3 && len(in) >= 16:
+		mulAddSliceSSSE3(t, in, out)
+		done = len(in) &^ 15
+	}
+
+	mulAddSliceGeneric(t, in[done:], out[done:])
+}
@@ -0,0 +1,257 @@
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// Registers of the kernels:
+// AX = table, SI = in, DI = out, CX = number of 16 or 32 byte blocks
+// X6/Y6 = low nibble table, X7/Y7 = high nibble table, X8/Y8 = 0x0F in every byte
+
+// func mulSliceSSSE3(t *mulTable, in, out []byte)
+TEXT ·mulSliceSSSE3(SB), NOSPLIT, $0-56
+	MOVQ   t+0(FP), AX
+	MOVQ   in_base+8(FP), SI
+	MOVQ   in_len+16(FP), CX
+	MOVQ   out_base+32(FP), DI
+	MOVOU  0(AX), X6
+	MOVOU  16(AX), X7
+	MOVQ   $0x0F, DX
+	MOVQ   DX, X8
+	PXOR   X0, X0
+	PSHUFB X0, X8
+	SHRQ   $4, CX
+	JZ     ssse3_mul_done
+
+ssse3_mul_loop:
+	MOVOU  (SI), X0
+	MOVOU  X0, X1
+	PSRLQ  $4, X1
+	PAND   X8, X0
+	PAND   X8, X1
+	MOVOU  X6, X2
+	MOVOU  X7, X3
+	PSHUFB X0, X2
+	PSHUFB X1, X3
+	PXOR   X3, X2
+	MOVOU  X2, (DI)
+	ADDQ   $16, SI
+	ADDQ   $16, DI
+	DECQ   CX
+	JNZ    ssse3_mul_loop
+
+ssse3_mul_done:
+	RET
+
+// func mulAddSliceSSSE3(t *mulTable, in, out []byte)
+TEXT ·mulAddSliceSSSE3(SB), NOSPLIT, $0-56
+	MOVQ   t+0(FP), AX
+	MOVQ   in_base+8(FP), SI
+	MOVQ   in_len+16(FP), CX
+	MOVQ   out_base+32(FP), DI
+	MOVOU  0(AX), X6
+	MOVOU  16(AX), X7
+	MOVQ   $0x0F, DX
+	MOVQ   DX, X8
+	PXOR   X0, X0
+	PSHUFB X0, X8
+	SHRQ   $4, CX
+	JZ     ssse3_muladd_done
+
+ssse3_muladd_loop:
+	MOVOU  (SI), X0
+	MOVOU  X0, X1
+	PSRLQ  $4, X1
+	PAND   X8, X0
+	PAND   X8, X1
+	MOVOU  X6, X2
+	MOVOU  X7, X3
+	PSHUFB X0, X2
+	PSHUFB X1, X3
+	PXOR   X3, X2
+	MOVOU  (DI), X4
+	PXOR   X4, X2
+	MOVOU  X2, (DI)
+	ADDQ   $16, SI
+	ADDQ   $16, DI
+	DECQ   CX
+	JNZ    ssse3_muladd_loop
+
+ssse3_muladd_done:
+	RET
+
+// func mulSliceAVX2(t *mulTable, in, out []byte)
+TEXT ·mulSliceAVX2(SB), NOSPLIT, $0-56
+	MOVQ           t+0(FP), AX
+	MOVQ           in_base+8(FP), SI
+	MOVQ           in_len+16(FP), CX
+	MOVQ           out_base+32(FP), DI
+	VBROADCASTI128 0(AX), Y6
+	VBROADCASTI128 16(AX), Y7
+	MOVQ           $0x0F, DX
+	MOVQ           DX, X8
+	VPBROADCASTB   X8, Y8
+	SHRQ           $5, CX
+	JZ             avx2_mul_done
+
+avx2_mul_loop:
+	VMOVDQU (SI), Y0
+	VPSRLQ  $4, Y0, Y1
+	VPAND   Y8, Y0, Y0
+	VPAND   Y8, Y1, Y1
+	VPSHUFB Y0, Y6, Y2
+	VPSHUFB Y1, Y7, Y3
+	VPXOR   Y3, Y2, Y2
+	VMOVDQU Y2, (DI)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	DECQ    CX
+	JNZ     avx2_mul_loop
+
+avx2_mul_done:
+	VZEROUPPER
+	RET
+
+// func mulAddSliceAVX2(t *mulTable, in, out []byte)
+TEXT ·mulAddSliceAVX2(SB), NOSPLIT, $0-56
+	MOVQ           t+0(FP), AX
+	MOVQ           in_base+8(FP), SI
+	MOVQ           in_len+16(FP), CX
+	MOVQ           out_base+32(FP), DI
+	VBROADCASTI128 0(AX), Y6
+	VBROADCASTI128 16(AX), Y7
+	MOVQ           $0x0F, DX
+	MOVQ           DX, X8
+	VPBROADCASTB   X8, Y8
+	SHRQ           $5, CX
+	JZ             avx2_muladd_done
+
+avx2_muladd_loop:
+	VMOVDQU (SI), Y0
+	VPSRLQ  $4, Y0, Y1
+	VPAND   Y8, Y0, Y0
+	VPAND   Y8, Y1, Y1
+	VPSHUFB Y0, Y6, Y2
+	VPSHUFB Y1, Y7, Y3
+	VPXOR   Y3, Y2, Y2
+	VPXOR   (DI), Y2, Y2
+	VMOVDQU Y2, (DI)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	DECQ    CX
+	JNZ     avx2_muladd_loop
+
+avx2_muladd_done:
+	VZEROUPPER
+	RET
+
+// GFNI kernels: AX = table, SI = in, DI = out, CX = number of 32 or 64 byte blocks, Y6/Z6 = bit matrix in every qword
+
+// func mulSliceGFNIAVX2(t *mulTable, in, out []byte)
+TEXT ·mulSliceGFNIAVX2(SB), NOSPLIT, $0-56
+	MOVQ         t+0(FP), AX
+	MOVQ         in_base+8(FP), SI
+	MOVQ         in_len+16(FP), CX
+	MOVQ         out_base+32(FP), DI
+	VPBROADCASTQ 32(AX), Y6
+	SHRQ         $5, CX
+	JZ           gfni_avx2_mul_done
+
+gfni_avx2_mul_loop:
+	VMOVDQU        (SI), Y0
+	VGF2P8AFFINEQB $0, Y6, Y0, Y1
+	VMOVDQU        Y1, (DI)
+	ADDQ           $32, SI
+	ADDQ           $32, DI
+	DECQ           CX
+	JNZ            gfni_avx2_mul_loop
+
+gfni_avx2_mul_done:
+	VZEROUPPER
+	RET
+
+// func mulAddSliceGFNIAVX2(t *mulTable, in, out []byte)
+TEXT ·mulAddSliceGFNIAVX2(SB), NOSPLIT, $0-56
+	MOVQ         t+0(FP), AX
+	MOVQ         in_base+8(FP), SI
+	MOVQ         in_len+16(FP), CX
+	MOVQ         out_base+32(FP), DI
+	VPBROADCASTQ 32(AX), Y6
+	SHRQ         $5, CX
+	JZ           gfni_avx2_muladd_done
+
+gfni_avx2_muladd_loop:
+	VMOVDQU        (SI), Y0
+	VGF2P8AFFINEQB $0, Y6, Y0, Y1
+	VPXOR          (DI), Y1, Y1
+	VMOVDQU        Y1, (DI)
+	ADDQ           $32, SI
+	ADDQ           $32, DI
+	DECQ           CX
+	JNZ            gfni_avx2_muladd_loop
+
+gfni_avx2_muladd_done:
+	VZEROUPPER
+	RET
+
+// func mulSliceGFNIAVX512(t *mulTable, in, out []byte)
+TEXT ·mulSliceGFNIAVX512(SB), NOSPLIT, $0-56
+	MOVQ         t+0(FP), AX
+	MOVQ         in_base+8(FP), SI
+	MOVQ         in_len+16(FP), CX
+	MOVQ         out_base+32(FP), DI
+	VPBROADCASTQ 32(AX), Z6
+	SHRQ         $6, CX
+	JZ           gfni_avx512_mul_done
+
+gfni_avx512_mul_loop:
+	VMOVDQU64      (SI), Z0
+	VGF2P8AFFINEQB $0, Z6, Z0, Z1
+	VMOVDQU64      Z1, (DI)
+	ADDQ           $64, SI
+	ADDQ           $64, DI
+	DECQ           CX
+	JNZ            gfni_avx512_mul_loop
+
+gfni_avx512_mul_done:
+	VZEROUPPER
+	RET
+
+// func mulAddSliceGFNIAVX512(t *mulTable, in, out []byte)
+TEXT ·mulAddSliceGFNIAVX512(SB), NOSPLIT, $0-56
+	MOVQ         t+0(FP), AX
+	MOVQ         in_base+8(FP), SI
+	MOVQ         in_len+16(FP), CX
+	MOVQ         out_base+32(FP), DI
+	VPBROADCASTQ 32(AX), Z6
+	SHRQ         $6, CX
+	JZ           gfni_avx512_muladd_done
+
+gfni_avx512_muladd_loop:
+	VMOVDQU64      (SI), Z0
+	VGF2P8AFFINEQB $0, Z6, Z0, Z1
+	VPXORQ         (DI), Z1, Z1
+	VMOVDQU64      Z1, (DI)
+	ADDQ           $64, SI
+	ADDQ           $64, DI
+	DECQ           CX
+	JNZ            gfni_avx512_muladd_loop
+
+gfni_avx512_muladd_done:
+	VZEROUPPER
+	RET
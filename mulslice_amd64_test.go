@@ -0,0 +1,107 @@
+package reedSolomon
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// Compare the output of every kernel available on this CPU with the pure Go version
+func TestMulSliceAssembly(t *testing.T) {
+	t.Log("Testing the SSSE3, AVX2 and GFNI kernels against the pure Go version")
+
+	kernels := []struct {
+		name      string
+		available bool
+		mul       func(*mulTable, []byte, []byte)
+		mulAdd    func(*mulTable, []byte, []byte)
+		blockSize int
+	}{
+		{"SSSE3", useSSSE3, mulSliceSSSE3, mulAddSliceSSSE3, 16},
+		{"AVX2", useAVX2, mulSliceAVX2, mulAddSliceAVX2, 32},
+		{"GFNI AVX2", useGFNIAVX2, mulSliceGFNIAVX2, mulAddSliceGFNIAVX2, 32},
+		{"GFNI AVX-512", useGFNIAVX512, mulSliceGFNIAVX512, mulAddSliceGFNIAVX512, 64},
+	}
+
+	// The CCSDS field checks that the kernels don't depend on the field polynomial
+	fields := []*galoisField{defaultField(), ccsdsField}
+
+	r := rand.New(rand.NewSource(1))
+	in := make([]byte, 1000)
+	r.Read(in)
+	initial := make([]byte, len(in))
+	r.Read(initial)
+
+	for _, k := range kernels {
+		if !k.available {
+			t.Logf("%s is not supported by this CPU", k.name)
+			continue
+		}
+
+		for _, gf := range fields {
+			for c := 0; c < 256; c++ {
+				table := gf.newMulTable(c)
+				for _, n := range []int{0, k.blockSize - 1, k.blockSize, 3*k.blockSize + 5, len(in)} {
+					done := n / k.blockSize * k.blockSize
+
+					expected := make([]byte, n)
+					mulSliceGeneric(table, in[:done], expected)
+					resp := make([]byte, n)
+					k.mul(table, in[:n], resp)
+					if !bytes.Equal(resp, expected) {
+						t.Fatalf("%s multiplication by %d of %d bytes does not match the pure Go version", k.name, c, n)
+					}
+
+					expected = append([]byte{}, initial[:n]...)
+					mulAddSliceGeneric(table, in[:done], expected)
+					resp = append([]byte{}, initial[:n]...)
+					k.mulAdd(table, in[:n], resp)
+					if !bytes.Equal(resp, expected) {
+						t.Fatalf("%s multiply and add by %d of %d bytes does not match the pure Go version", k.name, c, n)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMulSliceDispatch(t *testing.T) {
+	t.Log("Testing mulSlice with and without the assembly kernels")
+
+	in := make([]byte, 777)
+	for i := range in {
+		in[i] = byte(i * 31)
+	}
+	table := defaultField().newMulTable(0x8E)
+
+	expected := make([]byte, len(in))
+	mulSliceGeneric(table, in, expected)
+
+	ssse3, avx2, gfniAVX2, gfniAVX512 := useSSSE3, useAVX2, useGFNIAVX2, useGFNIAVX512
+	defer func() { useSSSE3, useAVX2, useGFNIAVX2, useGFNIAVX512 = ssse3, avx2, gfniAVX2, gfniAVX512 }()
+
+	// Turn the features off one at a time, from the fastest
+	features := [][4]bool{
+		{ssse3, avx2, gfniAVX2, gfniAVX512},
+		{ssse3, avx2, gfniAVX2, false},
+		{ssse3, avx2, false, false},
+		{ssse3, false, false, false},
+		{false, false, false, false},
+	}
+	for _, f := range features {
+		useSSSE3, useAVX2, useGFNIAVX2, useGFNIAVX512 = f[0], f[1], f[2], f[3]
+
+		resp := make([]byte, len(in))
+		mulSlice(table, in, resp)
+		if !bytes.Equal(resp, expected) {
+			t.Errorf("mulSlice (SSSE3 %t, AVX2 %t, GFNI AVX2 %t, GFNI AVX-512 %t) does not match the pure Go version", f[0], f[1], f[2], f[3])
+		}
+
+		mulAddSlice(table, in, resp) // x ^ x = 0
+		for i, b := range resp {
+			if b != 0 {
+				t.Fatalf("mulAddSlice (SSSE3 %t, AVX2 %t, GFNI AVX2 %t, GFNI AVX-512 %t) at index %d was expected to be 0, but it was %d instead.", f[0], f[1], f[2], f[3], i, b)
+			}
+		}
+	}
+}
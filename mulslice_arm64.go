@@ -0,0 +1,41 @@
+package reedSolomon
+
+// TBL is the NEON equivalent of PSHUFB: it looks up 16 bytes at once in a 16 byte table, so the kernels multiply
+// a whole register of symbols with the 2 nibble tables. NEON (Advanced SIMD) is part of every arm64 CPU, the
+// variable only lets the tests compare the kernels with the pure Go version.
+var useNEON = true
+
+// Multiply the first len(in)/16*16 symbols, out must be at least as long as in.
+// Implemented in mulslice_arm64.s
+//
+//go:noescape
+func mulSliceNEON(t *mulTable, in, out []byte)
+
+//go:noescape
+func mulAddSliceNEON(t *mulTable, in, out []byte)
+
+// out[i] = c * in[i], out must be at least as long as in
+func mulSlice(t *mulTable, in, out []byte) {
+	out = out[:len(in)]
+	done := 0
+
+	if useNEON && len(in) >= 16 {
+		mulSliceNEON(t, in, out)
+		done = len(in) &^ 15
+	}
+
+	mulSliceGeneric(t, in[done:], out[done:])
+}
+
+// out[i] ^= c * in[i], out must be at least as long as in
+func mulAddSlice(t *mulTable, in, out []byte) {
+	out = out[:len(in)]
+	done := 0
+
+	if useNEON && len(in) >= 16 {
+		mulAddSliceNEON(t, in, out)
+		done = len(in) &^ 15
+	}
+
+	mulAddSliceGeneric(t, in[done:], out[done:])
+}
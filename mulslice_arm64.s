@@ -0,0 +1,57 @@
+#include "textflag.h"
+
+// Registers of the kernels:
+// R0 = table, R1 = in, R2 = number of 16 byte blocks, R3 = out
+// V6 = low nibble table, V7 = high nibble table, V8 = 0x0F in every byte
+
+// func mulSliceNEON(t *mulTable, in, out []byte)
+TEXT ·mulSliceNEON(SB), NOSPLIT, $0-56
+	MOVD  t+0(FP), R0
+	MOVD  in_base+8(FP), R1
+	MOVD  in_len+16(FP), R2
+	MOVD  out_base+32(FP), R3
+	VLD1  (R0), [V6.B16, V7.B16]
+	VMOVI $0x0F, V8.B16
+	LSR   $4, R2, R2
+	CBZ   R2, neon_mul_done
+
+neon_mul_loop:
+	VLD1.P 16(R1), [V0.B16]
+	VUSHR  $4, V0.B16, V1.B16
+	VAND   V8.B16, V0.B16, V0.B16
+	VTBL   V0.B16, [V6.B16], V2.B16
+	VTBL   V1.B16, [V7.B16], V3.B16
+	VEOR   V3.B16, V2.B16, V2.B16
+	VST1.P [V2.B16], 16(R3)
+	SUBS   $1, R2, R2
+	BNE    neon_mul_loop
+
+neon_mul_done:
+	RET
+
+// func mulAddSliceNEON(t *mulTable, in, out []byte)
+TEXT ·mulAddSliceNEON(SB), NOSPLIT, $0-56
+	MOVD  t+0(FP), R0
+	MOVD  in_base+8(FP), R1
+	MOVD  in_len+16(FP), R2
+	MOVD  out_base+32(FP), R3
+	VLD1  (R0), [V6.B16, V7.B16]
+	VMOVI $0x0F, V8.B16
+	LSR   $4, R2, R2
+	CBZ   R2, neon_muladd_done
+
+neon_muladd_loop:
+	VLD1.P 16(R1), [V0.B16]
+	VLD1   (R3), [V4.B16]
+	VUSHR  $4, V0.B16, V1.B16
+	VAND   V8.B16, V0.B16, V0.B16
+	VTBL   V0.B16, [V6.B16], V2.B16
+	VTBL   V1.B16, [V7.B16], V3.B16
+	VEOR   V3.B16, V2.B16, V2.B16
+	VEOR   V4.B16, V2.B16, V2.B16
+	VST1.P [V2.B16], 16(R3)
+	SUBS   $1, R2, R2
+	BNE    neon_muladd_loop
+
+neon_muladd_done:
+	RET
@@ -0,0 +1,79 @@
+package reedSolomon
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// Compare the output of the NEON kernels with the pure Go version
+func TestMulSliceAssembly(t *testing.T) {
+	t.Log("Testing the NEON kernels against the pure Go version")
+
+	// The CCSDS field checks that the kernels don't depend on the field polynomial
+	fields := []*galoisField{defaultField(), ccsdsField}
+
+	r := rand.New(rand.NewSource(1))
+	in := make([]byte, 1000)
+	r.Read(in)
+	initial := make([]byte, len(in))
+	r.Read(initial)
+
+	for _, gf := range fields {
+		for c := 0; c < 256; c++ {
+			table := gf.newMulTable(c)
+			for _, n := range []int{0, 15, 16, 53, len(in)} {
+				done := n / 16 * 16
+
+				expected := make([]byte, n)
+				mulSliceGeneric(table, in[:done], expected)
+				resp := make([]byte, n)
+				mulSliceNEON(table, in[:n], resp)
+				if !bytes.Equal(resp, expected) {
+					t.Fatalf("NEON multiplication by %d of %d bytes does not match the pure Go version", c, n)
+				}
+
+				expected = append([]byte{}, initial[:n]...)
+				mulAddSliceGeneric(table, in[:done], expected)
+				resp = append([]byte{}, initial[:n]...)
+				mulAddSliceNEON(table, in[:n], resp)
+				if !bytes.Equal(resp, expected) {
+					t.Fatalf("NEON multiply and add by %d of %d bytes does not match the pure Go version", c, n)
+				}
+			}
+		}
+	}
+}
+
+func TestMulSliceDispatch(t *testing.T) {
+	t.Log("Testing mulSlice with and without the NEON kernels")
+
+	in := make([]byte, 777)
+	for i := range in {
+		in[i] = byte(i * 31)
+	}
+	table := defaultField().newMulTable(0x8E)
+
+	expected := make([]byte, len(in))
+	mulSliceGeneric(table, in, expected)
+
+	neon := useNEON
+	defer func() { useNEON = neon }()
+
+	for _, f := range []bool{true, false} {
+		useNEON = f
+
+		resp := make([]byte, len(in))
+		mulSlice(table, in, resp)
+		if !bytes.Equal(resp, expected) {
+			t.Errorf("mulSlice (NEON %t) does not match the pure Go version", f)
+		}
+
+		mulAddSlice(table, in, resp) // x ^ x = 0
+		for i, b := range resp {
+			if b != 0 {
+				t.Fatalf("mulAddSlice (NEON %t) at index %d was expected to be 0, but it was %d instead.", f, i, b)
+			}
+		}
+	}
+}
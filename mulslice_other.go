@@ -0,0 +1,13 @@
+//go:build !amd64 && !arm64
+
+package reedSolomon
+
+// out[i] = c * in[i], out must be at least as long as in
+func mulSlice(t *mulTable, in, out []byte) {
+	mulSliceGeneric(t, in, out)
+}
+
+// out[i] ^= c * in[i], out must be at least as long as in
+func mulAddSlice(t *mulTable, in, out []byte) {
+	mulAddSliceGeneric(t, in, out)
+}
@@ -0,0 +1,87 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+func TestMulSlice(t *testing.T) {
+	t.Log("Testing the nibble table multiplication of slices")
+
+	in := make([]byte, 256)
+	for i := range in {
+		in[i] = byte(i)
+	}
+	out := make([]byte, 256)
+	acc := make([]byte, 256)
+
+	for c := 0; c < 256; c++ {
+		table := defaultField().newMulTable(c)
+		mulSlice(table, in, out)
+
+		for i := range acc {
+			acc[i] = byte(i * 7)
+		}
+		mulAddSlice(table, in, acc)
+
+		for i, r := range out {
+			expected := gfMultiplication(c, int(in[i]))
+			if int(r) != expected {
+				t.Fatalf("%d * %d was expected to be %d, but it was %d instead.", c, in[i], expected, r)
+			}
+			if int(acc[i]) != expected^(i*7&0xFF) {
+				t.Fatalf("%d * %d + %d was expected to be %d, but it was %d instead.", c, in[i], i*7&0xFF, expected^(i*7&0xFF), acc[i])
+			}
+		}
+	}
+}
+
+func TestMulSliceSmallField(t *testing.T) {
+	t.Log("Testing the nibble table multiplication in GF(64)")
+
+	gf := newGaloisField(6, 0x43, 1)
+	in := make([]int, 64)
+	for i := range in {
+		in[i] = i
+	}
+	out := make([]int, 64)
+
+	for c := 0; c < 64; c++ {
+		mulSliceInt(gf.newMulTable(c), in, out)
+		for i, r := range out {
+			if r != gf.multiplication(c, i) {
+				t.Fatalf("%d * %d was expected to be %d, but it was %d instead.", c, i, gf.multiplication(c, i), r)
+			}
+		}
+	}
+}
+
+// Apply the bit matrix like VGF2P8AFFINEQB does, so that the matrices are checked on every platform
+func applyAffineMatrix(matrix uint64, x byte) byte {
+	var r byte
+	for i := uint(0); i < 8; i++ {
+		row := byte(matrix >> (8 * (7 - i)))
+		parity := byte(0)
+		for b := row & x; b != 0; b >>= 1 {
+			parity ^= b & 1
+		}
+		r |= parity << i
+	}
+	return r
+}
+
+func TestMulTableAffineMatrix(t *testing.T) {
+	t.Log("Testing the bit matrices of the GFNI multiplication")
+
+	fields := []*galoisField{defaultField(), newGaloisField(8, 0x12D, 0), ccsdsField, newGaloisField(6, 0x43, 1)}
+	for _, gf := range fields {
+		for c := 0; c <= gf.order; c++ {
+			matrix := gf.newMulTable(c).matrix
+			for x := 0; x <= gf.order; x++ {
+				expected := gf.multiplication(c, x)
+				if r := applyAffineMatrix(matrix, byte(x)); int(r) != expected {
+					t.Fatalf("%d * %d was expected to be %d, but it was %d instead.", c, x, expected, r)
+				}
+			}
+		}
+	}
+}
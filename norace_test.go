@@ -0,0 +1,5 @@
+//go:build !race
+
+package reedSolomon
+
+const raceEnabled = false
@@ -0,0 +1,77 @@
+package reedSolomon
+
+import (
+	"fmt"
+)
+
+// PDF417 (ISO/IEC 15438) codewords are numbers between 0 and 928, its Reed-Solomon code works over the prime field GF(929)
+// with 3 as generator and a first consecutive root of 1.
+var pdf417Field = newPrimeField(929, 3, 1)
+
+// Maximum number of codewords (data and ecc) in a PDF417 symbol
+const pdf417MaxCodewords = 928
+
+// PDF417Profile describes the error correction of a PDF417 security level: level L adds 2^(L+1) ecc codewords.
+// It doesn't depend on InitGaloisFields.
+type PDF417Profile struct {
+	Level     int
+	generator []int
+}
+
+// NewPDF417Profile creates the error correction profile of a security level (0 to 8)
+func NewPDF417Profile(level int) (*PDF417Profile, error) {
+	if level < 0 || level > 8 {
+		return nil, fmt.Errorf("Invalid PDF417 security level %d (must be between 0 and 8)", level)
+	}
+
+	return &PDF417Profile{
+		Level:     level,
+		generator: pdf417Field.generatorPolynomial(2 << uint(level)),
+	}, nil
+}
+
+// EccCodewords returns the number of ecc codewords of the security level
+func (p *PDF417Profile) EccCodewords() int {
+	return 2 << uint(p.Level)
+}
+
+// Encode appends the ecc codewords to the data codewords (starting with the symbol length descriptor)
+func (p *PDF417Profile) Encode(data []int) ([]int, error) {
+	if len(data) == 0 || len(data)+p.EccCodewords() > pdf417MaxCodewords {
+		return []int{}, fmt.Errorf("Invalid number of data codewords %d (must be between 1 and %d)", len(data), pdf417MaxCodewords-p.EccCodewords())
+	}
+	for i, c := range data {
+		if c < 0 || c >= pdf417Field.modulus {
+			return []int{}, fmt.Errorf("Codeword %d is out of range (%d)", i, c)
+		}
+	}
+
+	return pdf417Field.encodeMessage(data, p.generator), nil
+}
+
+// Decode corrects the codewords read from the symbol and returns the data codewords.
+// erasedIndices are positions of codewords that could not be read.
+// If the codewords can not be corrected the data codewords are returned as received along with the error.
+func (p *PDF417Profile) Decode(codewords []int, erasedIndices []int) ([]int, BlockStats, error) {
+	stats := BlockStats{Erasures: len(erasedIndices)}
+	nsym := p.EccCodewords()
+
+	if len(codewords) <= nsym || len(codewords) > pdf417MaxCodewords {
+		return []int{}, stats, fmt.Errorf("Invalid number of codewords %d (must be between %d and %d)", len(codewords), nsym+1, pdf417MaxCodewords)
+	}
+	for i, c := range codewords {
+		if c < 0 || c >= pdf417Field.modulus {
+			return []int{}, stats, fmt.Errorf("Codeword %d is out of range (%d)", i, c)
+		}
+	}
+
+	eccStart := len(codewords) - nsym
+	corrected, err := pdf417Field.decode(codewords, nsym, erasedIndices)
+	if err != nil {
+		stats.Err = err
+		return codewords[:eccStart], stats, err // keep the data codewords as received
+	}
+
+	stats.Corrected = countCorrections(codewords, corrected)
+	return corrected[:eccStart], stats, nil
+}
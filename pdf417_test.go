@@ -0,0 +1,120 @@
+package reedSolomon
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestPDF417Generator(t *testing.T) {
+	t.Log("Testing the PDF417 generator polynomials")
+
+	// Level 0: (x - 3)(x - 9) = x^2 - 12x + 27, the coefficients of ISO/IEC 15438 Annex F are 27 and 917 (-12 mod 929)
+	p, _ := NewPDF417Profile(0)
+	expected := []int{1, 917, 27}
+
+	for i, r := range p.generator {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+
+	if _, err := NewPDF417Profile(9); err == nil {
+		t.Error("Should have stated that the security level is invalid")
+	}
+}
+
+func TestPDF417Encode(t *testing.T) {
+	t.Log("Testing PDF417 encoding")
+
+	// "PDF417" at security level 1 (example of ISO/IEC 15438)
+	p, _ := NewPDF417Profile(1)
+	data := []int{5, 453, 178, 121, 239}
+
+	expected := []int{5, 453, 178, 121, 239, 452, 327, 657, 619}
+	resp, err := p.Encode(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+}
+
+func TestPDF417Decode(t *testing.T) {
+	t.Log("Testing PDF417 errors and erasures correction")
+
+	r := rand.New(rand.NewSource(1))
+
+	for level := 0; level <= 8; level++ {
+		p, _ := NewPDF417Profile(level)
+		nsym := p.EccCodewords()
+
+		data := make([]int, 900-nsym)
+		if level < 4 {
+			data = data[:40]
+		}
+		for i := range data {
+			data[i] = r.Intn(900)
+		}
+		codewords, _ := p.Encode(data)
+
+		// Erase a third of the ecc budget and use the rest for errors
+		numErasures := nsym / 3
+		numErrors := (nsym - numErasures) / 2
+		positions := r.Perm(len(codewords))
+		erasures := positions[:numErasures]
+		for _, i := range erasures {
+			codewords[i] = 0
+		}
+		for _, i := range positions[numErasures : numErasures+numErrors] {
+			codewords[i] = (codewords[i] + 1 + r.Intn(928)) % 929
+		}
+
+		resp, stats, err := p.Decode(codewords, erasures)
+		if err != nil {
+			t.Fatalf("Could not decode level %d: %s", level, err)
+		}
+		if stats.Erasures != numErasures {
+			t.Errorf("%d erasures were expected, but there were %d instead.", numErasures, stats.Erasures)
+		}
+		for i, c := range resp {
+			if c != data[i] {
+				t.Errorf("Level %d: response at index %d was expected to be %d, but it was %d instead.", level, i, data[i], c)
+			}
+		}
+	}
+}
+
+func TestPDF417TooManyErrors(t *testing.T) {
+	t.Log("Testing PDF417 with too many errors")
+
+	p, _ := NewPDF417Profile(2)
+	codewords, _ := p.Encode([]int{10, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	for i := 0; i < 6; i++ {
+		codewords[i] = (codewords[i] + 100) % 929
+	}
+
+	_, _, err := p.Decode(codewords, []int{})
+	if err == nil {
+		t.Error("Should have stated that there are too many errors to correct")
+	}
+}
+
+func TestPDF417ErasureOutOfRange(t *testing.T) {
+	t.Log("Testing PDF417 with an erasure out of the codewords")
+
+	p, _ := NewPDF417Profile(2)
+	codewords, _ := p.Encode([]int{10, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	// The codewords are correct, the erasure must still be rejected
+	for _, e := range []int{-1, len(codewords)} {
+		_, _, err := p.Decode(codewords, []int{e})
+		if err == nil || err.Error() != fmt.Sprintf("Erasure position %d is out of range", e) {
+			t.Errorf("Should have stated that the erasure position %d is out of range", e)
+		}
+	}
+}
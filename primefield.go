@@ -0,0 +1,274 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// primeField holds the look up tables of a prime field GF(p), used by codes like PDF417 (GF(929)).
+// Unlike GF(2^m) the addition is not a XOR: every operation is done modulo p, and subtraction differs from addition
+// (so the signs of the Forney algorithm matter and the formal derivative multiplies by the degree).
+type primeField struct {
+	modulus   int   // p, the elements of the field are 0 to p-1
+	order     int   // number of non-zero elements (p - 1), this is also the maximum length of a codeword
+	exponents []int // anti-log table: exponents[i] = generator^i, doubled so that we don't need to mod order when multiplying
+	logs      []int // log table, log[0] is impossible and thus unused
+	fcr       int   // first consecutive root
+}
+
+// newPrimeField precomputes the tables of GF(modulus) using a primitive element of the field as generator
+func newPrimeField(modulus, generator, firstConsecutiveRoot int) *primeField {
+	pf := &primeField{
+		modulus:   modulus,
+		order:     modulus - 1,
+		exponents: make([]int, 2*(modulus-1)),
+		logs:      make([]int, modulus),
+		fcr:       firstConsecutiveRoot,
+	}
+
+	x := 1
+	for i := 0; i < pf.order; i++ {
+		pf.exponents[i] = x
+		pf.logs[x] = i
+		x = (x * generator) % modulus
+	}
+	copy(pf.exponents[pf.order:], pf.exponents[:pf.order])
+
+	return pf
+}
+
+// ***************************
+// Single value Manipulations
+// ***************************
+
+func (pf *primeField) addition(x, y int) int {
+	return (x + y) % pf.modulus
+}
+
+func (pf *primeField) subtraction(x, y int) int {
+	return (x + pf.modulus - y) % pf.modulus
+}
+
+func (pf *primeField) multiplication(x, y int) int {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return pf.exponents[pf.logs[x]+pf.logs[y]]
+}
+
+func (pf *primeField) inverse(x int) int {
+	return pf.exponents[pf.order-pf.logs[x]]
+}
+
+// generator^power, power can be negative
+func (pf *primeField) alphaPower(power int) int {
+	power %= pf.order
+	if power < 0 {
+		power += pf.order
+	}
+	return pf.exponents[power]
+}
+
+// ***************************
+// Polynomial Manipulations
+// ***************************
+
+// Evaluates a polynomial (biggest degree first) with Horner's scheme
+func (pf *primeField) polynomialEval(poly []int, x int) int {
+	y := 0
+	for _, coef := range poly {
+		y = pf.addition(pf.multiplication(y, x), coef)
+	}
+	return y
+}
+
+// Evaluates a polynomial stored lowest degree first (the convention used by the decoder)
+func (pf *primeField) polynomialEvalLow(poly []int, x int) int {
+	y := 0
+	for i := len(poly) - 1; i >= 0; i-- {
+		y = pf.addition(pf.multiplication(y, x), poly[i])
+	}
+	return y
+}
+
+func (pf *primeField) polynomialMultiplication(p, q []int) []int {
+	r := make([]int, len(p)+len(q)-1)
+	for j := range q {
+		for i := range p {
+			r[i+j] = pf.addition(r[i+j], pf.multiplication(p[i], q[j]))
+		}
+	}
+	return r
+}
+
+// ***************************
+// Encoding
+// ***************************
+
+// Compute the generator polynomial for nsym error correcting symbols (biggest degree first).
+// g(x) = (x - a^fcr) * (x - a^(fcr+1)) * ... * (x - a^(fcr+nsym-1))
+func (pf *primeField) generatorPolynomial(nsym int) []int {
+	g := []int{1}
+	for i := 0; i < nsym; i++ {
+		g = pf.polynomialMultiplication(g, []int{1, pf.subtraction(0, pf.alphaPower(i+pf.fcr))})
+	}
+	return g
+}
+
+// Systematic encoding: the ecc symbols are the opposite of the remainder of msg(x) * x^nsym divided by the generator,
+// so that the codeword msg(x) * x^nsym - remainder(x) is a multiple of the generator.
+func (pf *primeField) encodeMessage(msg, generator []int) []int {
+	nsym := len(generator) - 1
+	ecc := make([]int, nsym)
+
+	for _, m := range msg {
+		coef := m
+		if nsym > 0 {
+			coef = pf.addition(coef, ecc[0])
+			copy(ecc, ecc[1:]) // shift the remainder by one degree
+			ecc[nsym-1] = 0
+		}
+
+		if coef != 0 {
+			for j := 1; j < len(generator); j++ { // skip the first coefficient of the (monic) generator
+				ecc[j-1] = pf.subtraction(ecc[j-1], pf.multiplication(generator[j], coef))
+			}
+		}
+	}
+
+	msgOut := make([]int, 0, len(msg)+nsym)
+	msgOut = append(msgOut, msg...)
+	for _, e := range ecc {
+		msgOut = append(msgOut, pf.subtraction(0, e))
+	}
+	return msgOut
+}
+
+// ***************************
+// Decoding
+// ***************************
+
+// Corrects the errors and erasures of a codeword with nsym ecc symbols (biggest degree first, like Decode).
+// The message is not modified, the corrected codeword is returned.
+func (pf *primeField) decode(msg []int, nsym int, erasedIndices []int) ([]int, error) {
+	n := len(msg)
+	if n > pf.order {
+		return []int{}, fmt.Errorf("Message is too long (%d when max is %d)", n, pf.order)
+	}
+	if len(erasedIndices) > nsym {
+		return []int{}, errors.New("Too many erasures to correct")
+	}
+	for _, p := range erasedIndices {
+		if p < 0 || p >= n {
+			return []int{}, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+	}
+
+	msgOut := make([]int, n)
+	copy(msgOut, msg)
+
+	// Syndromes, lowest degree first: S_i = msg(a^(i+fcr))
+	synd := make([]int, nsym)
+	clean := true
+	for i := range synd {
+		synd[i] = pf.polynomialEval(msgOut, pf.alphaPower(i+pf.fcr))
+		if synd[i] != 0 {
+			clean = false
+		}
+	}
+	if clean {
+		return msgOut, nil
+	}
+
+	// Erasure locator: product of (1 - X_j x) where X_j = a^(degree of the erased symbol)
+	erasureLoc := []int{1}
+	for _, p := range erasedIndices {
+		erasureLoc = pf.polynomialMultiplication(erasureLoc, []int{1, pf.subtraction(0, pf.alphaPower(n-1-p))})
+	}
+
+	// Berlekamp-Massey, starting from the erasure locator so that the result locates both errors and erasures
+	errLoc := erasureLoc
+	oldLoc := erasureLoc
+	numErasures := len(erasedIndices)
+	L := numErasures
+
+	for r := numErasures; r < nsym; r++ {
+		// Discrepancy
+		delta := 0
+		for j := 0; j < len(errLoc) && j <= r; j++ {
+			delta = pf.addition(delta, pf.multiplication(errLoc[j], synd[r-j]))
+		}
+
+		oldLoc = append([]int{0}, oldLoc...) // multiply by x
+
+		if delta != 0 {
+			newLoc := make([]int, len(oldLoc))
+			if len(errLoc) > len(oldLoc) {
+				newLoc = make([]int, len(errLoc))
+			}
+			copy(newLoc, errLoc)
+			for j, c := range oldLoc {
+				newLoc[j] = pf.subtraction(newLoc[j], pf.multiplication(delta, c))
+			}
+
+			if 2*L <= r+numErasures {
+				L = r + numErasures + 1 - L
+				inv := pf.inverse(delta)
+				oldLoc = make([]int, len(errLoc))
+				for j, c := range errLoc {
+					oldLoc[j] = pf.multiplication(c, inv)
+				}
+			}
+			errLoc = newLoc
+		}
+	}
+
+	for len(errLoc) > 1 && errLoc[len(errLoc)-1] == 0 {
+		errLoc = errLoc[:len(errLoc)-1] // drop the null coefficients of the highest degrees
+	}
+	errs := len(errLoc) - 1
+	if 2*(errs-numErasures)+numErasures > nsym {
+		return []int{}, fmt.Errorf("Too many errors to correct: %d of max %d (Found at least %d errors and %d erasures)", 2*(errs-numErasures)+numErasures, nsym, errs-numErasures, numErasures)
+	}
+
+	// Chien search: the symbol at position p is in error if the locator is null at X^-1
+	errPos := []int{}
+	for p := 0; p < n; p++ {
+		if pf.polynomialEvalLow(errLoc, pf.alphaPower(-(n-1-p))) == 0 {
+			errPos = append(errPos, p)
+		}
+	}
+	if len(errPos) != errs {
+		return []int{}, errors.New("too many (or few) errors found by Chien Search for the errata locator polynomial")
+	}
+
+	// Error evaluator: Omega(x) = Synd(x) * Lambda(x) mod x^nsym
+	errorPolynomial := pf.polynomialMultiplication(synd, errLoc)[:nsym]
+
+	// Formal derivative of the locator (the degree is a multiplier here, it isn't reduced to 0 or 1 like in GF(2^m))
+	errLocPrime := make([]int, len(errLoc)-1)
+	for i := 1; i < len(errLoc); i++ {
+		errLocPrime[i-1] = pf.multiplication(i%pf.modulus, errLoc[i])
+	}
+
+	// Forney algorithm: e_j = -X_j^(1-fcr) * Omega(X_j^-1) / Lambda'(X_j^-1)
+	for _, p := range errPos {
+		xInverse := pf.alphaPower(-(n - 1 - p))
+		denominator := pf.polynomialEvalLow(errLocPrime, xInverse)
+		if denominator == 0 {
+			return []int{}, errors.New("Could not correct message")
+		}
+		y := pf.multiplication(pf.polynomialEvalLow(errorPolynomial, xInverse), pf.alphaPower((n-1-p)*(1-pf.fcr)))
+		magnitude := pf.subtraction(0, pf.multiplication(y, pf.inverse(denominator)))
+		msgOut[p] = pf.subtraction(msgOut[p], magnitude)
+	}
+
+	// Check if the final message is fully repaired
+	for i := 0; i < nsym; i++ {
+		if pf.polynomialEval(msgOut, pf.alphaPower(i+pf.fcr)) != 0 {
+			return []int{}, errors.New("Could not correct message")
+		}
+	}
+
+	return msgOut, nil
+}
@@ -0,0 +1,131 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PuncturedCode is a Reed-Solomon code where some ecc symbols are not transmitted (punctured) to raise the code rate.
+// The decoder re-inserts the punctured symbols as erasures: each of them costs one ecc symbol of correction capacity.
+// The galois field look up tables must be initialized (see InitGaloisFields) before use.
+type PuncturedCode struct {
+	numberEccSymbols int
+	punctured        []bool // punctured[i] is true if the ith ecc symbol is not transmitted
+	numPunctured     int
+	generator        []int
+}
+
+// NewPuncturedCode creates a code with numberEccSymbols ecc symbols, of which the ones at the indices of puncturedParity
+// (0 being the first ecc symbol) are dropped. At least one ecc symbol has to be transmitted.
+func NewPuncturedCode(numberEccSymbols int, puncturedParity []int) (*PuncturedCode, error) {
+	if numberEccSymbols <= 0 || numberEccSymbols >= 255 {
+		return nil, fmt.Errorf("Invalid number of ecc symbols (%d when it must be between 1 and 254)", numberEccSymbols)
+	}
+
+	punctured := make([]bool, numberEccSymbols)
+	for _, i := range puncturedParity {
+		if i < 0 || i >= numberEccSymbols {
+			return nil, fmt.Errorf("Punctured position %d is out of range", i)
+		}
+		if punctured[i] {
+			return nil, fmt.Errorf("Punctured position %d is repeated", i)
+		}
+		punctured[i] = true
+	}
+	if len(puncturedParity) >= numberEccSymbols {
+		return nil, errors.New("Too many punctured symbols (at least one ecc symbol has to be transmitted)")
+	}
+
+	return &PuncturedCode{
+		numberEccSymbols: numberEccSymbols,
+		punctured:        punctured,
+		numPunctured:     len(puncturedParity),
+		generator:        generatorPolynomial(numberEccSymbols),
+	}, nil
+}
+
+// TransmittedEccSymbols returns the number of ecc symbols that are transmitted
+func (c *PuncturedCode) TransmittedEccSymbols() int {
+	return c.numberEccSymbols - c.numPunctured
+}
+
+// PuncturedPositions returns the indices of the punctured ecc symbols, in increasing order
+func (c *PuncturedCode) PuncturedPositions() []int {
+	positions := []int{}
+	for i, p := range c.punctured {
+		if p {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// Encode returns the message followed by its transmitted ecc symbols
+func (c *PuncturedCode) Encode(msg []int) ([]int, error) {
+	if len(msg)+c.numberEccSymbols > 255 {
+		return []int{}, fmt.Errorf("Message is too long (%d when max is 255)", len(msg)+c.numberEccSymbols)
+	}
+
+	codeword := encodeMessage(msg, c.generator)
+
+	msgOut := make([]int, 0, len(msg)+c.TransmittedEccSymbols())
+	msgOut = append(msgOut, msg...)
+	for i, symbol := range codeword[len(msg):] {
+		if !c.punctured[i] {
+			msgOut = append(msgOut, symbol)
+		}
+	}
+	return msgOut, nil
+}
+
+// Decode re-inserts the punctured ecc symbols as erasures, corrects the codeword and returns the message.
+// erasedIndices are positions in the received (punctured) codeword. The statistics only count the received symbols.
+// If the codeword can not be corrected the message is returned as received along with the error.
+func (c *PuncturedCode) Decode(received []int, erasedIndices []int) ([]int, BlockStats, error) {
+	stats := BlockStats{Erasures: len(erasedIndices)}
+
+	msgLength := len(received) - c.TransmittedEccSymbols()
+	if msgLength <= 0 {
+		return []int{}, stats, fmt.Errorf("Message is too short (%d symbols when more than %d are required)", len(received), c.TransmittedEccSymbols())
+	}
+	if msgLength+c.numberEccSymbols > 255 {
+		return []int{}, stats, fmt.Errorf("Message is too long (%d when max is 255)", msgLength+c.numberEccSymbols)
+	}
+
+	// Map the received symbols to their position in the full codeword, the punctured ones are erasures
+	codeword := make([]int, msgLength+c.numberEccSymbols)
+	positions := make([]int, len(received))
+	erasures := []int{}
+
+	r := 0
+	for p := range codeword {
+		if p >= msgLength && c.punctured[p-msgLength] {
+			erasures = append(erasures, p)
+			continue
+		}
+		codeword[p] = received[r]
+		positions[r] = p
+		r++
+	}
+
+	for _, e := range erasedIndices {
+		if e < 0 || e >= len(received) {
+			return []int{}, stats, fmt.Errorf("Erasure position %d is out of range", e)
+		}
+		erasures = append(erasures, positions[e])
+	}
+
+	correctedMsg, correctedEcc, err := Decode(codeword, c.numberEccSymbols, erasures) // codeword is a copy, it can be modified
+	if err != nil {
+		stats.Err = err
+		return received[:msgLength], stats, err // keep the message as received
+	}
+
+	corrected := append(correctedMsg, correctedEcc...)
+	for r, p := range positions {
+		if received[r] != corrected[p] {
+			stats.Corrected++
+		}
+	}
+	return correctedMsg, stats, nil
+}
@@ -0,0 +1,78 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+func TestPuncturedCodeEncode(t *testing.T) {
+	t.Log("Testing that the punctured ecc symbols are dropped")
+
+	c, err := NewPuncturedCode(8, []int{1, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []int{10, 20, 30, 40, 50}
+	full, _ := Encode(msg, 8)
+	expected := []int{10, 20, 30, 40, 50, full[5], full[7], full[8], full[9], full[10], full[12]}
+
+	resp, err := c.Encode(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != len(expected) {
+		t.Fatalf("%d symbols were expected, but there were %d instead.", len(expected), len(resp))
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+}
+
+func TestPuncturedCodeDecode(t *testing.T) {
+	t.Log("Testing decoding a punctured code with errors and erasures")
+
+	// 10 ecc symbols, 4 punctured: 6 left for 2 errors and 2 erasures
+	c, _ := NewPuncturedCode(10, []int{0, 3, 5, 9})
+	msg := []int{72, 101, 108, 108, 111, 32, 119, 111, 114, 108, 100}
+	received, _ := c.Encode(msg)
+
+	received[1] ^= 0x33
+	received[len(received)-1] ^= 0x44 // error on a transmitted ecc symbol
+	received[4] = 0
+	received[12] = 0
+
+	resp, stats, err := c.Decode(received, []int{4, 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Erasures != 2 || stats.Corrected != 4 {
+		t.Errorf("2 erasures and 4 corrections were expected, but there were %d and %d instead.", stats.Erasures, stats.Corrected)
+	}
+	for i, r := range resp {
+		if r != msg[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, msg[i], r)
+		}
+	}
+
+	// One more erasure is beyond the capacity left by the puncturing
+	received[0] = 0
+	if _, _, err := c.Decode(received, []int{0, 4, 12}); err == nil {
+		t.Error("Should have stated that there are too many errors to correct")
+	}
+}
+
+func TestPuncturedCodeInvalid(t *testing.T) {
+	t.Log("Testing invalid puncturing patterns")
+
+	if _, err := NewPuncturedCode(4, []int{0, 1, 2, 3}); err == nil {
+		t.Error("Should have stated that at least one ecc symbol has to be transmitted")
+	}
+	if _, err := NewPuncturedCode(4, []int{2, 2}); err == nil {
+		t.Error("Should have stated that a punctured position is repeated")
+	}
+	if _, err := NewPuncturedCode(4, []int{4}); err == nil {
+		t.Error("Should have stated that a punctured position is out of range")
+	}
+}
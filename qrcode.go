@@ -0,0 +1,126 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// QRLevel is the error correction level of a QR Code
+type QRLevel int
+
+// QR Code error correction levels (roughly the percentage of codewords that can be restored)
+const (
+	QRLevelL QRLevel = iota // 7%
+	QRLevelM                // 15%
+	QRLevelQ                // 25%
+	QRLevelH                // 30%
+)
+
+// Number of ecc codewords in each block, indexed by level and version (index 0 is unused)
+var qrEccCodewordsPerBlock = [4][41]int{
+	{0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},  // L
+	{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28}, // M
+	{0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30}, // Q
+	{0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30}, // H
+}
+
+// Number of blocks (group 1 and group 2 together), indexed by level and version (index 0 is unused)
+var qrNumBlocks = [4][41]int{
+	{0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},              // L
+	{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},     // M
+	{0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},  // Q
+	{0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81}, // H
+}
+
+// QRProfile describes the error correction of a QR Code version and level: how many blocks there are,
+// how many data and ecc codewords each of them holds and how they are interleaved in the symbol.
+// The galois field look up tables must be initialized with InitGaloisFields(285, 0).
+type QRProfile struct {
+	Version int
+	Level   QRLevel
+	layout  *interleavedLayout
+}
+
+// NewQRProfile creates the error correction profile of a QR Code version (1 to 40) and level
+func NewQRProfile(version int, level QRLevel) (*QRProfile, error) {
+	if version < 1 || version > 40 {
+		return nil, fmt.Errorf("Invalid QR Code version %d (must be between 1 and 40)", version)
+	}
+	if level < QRLevelL || level > QRLevelH {
+		return nil, fmt.Errorf("Invalid QR Code error correction level %d", level)
+	}
+
+	// The blocks of group 1 are one data codeword shorter than the blocks of group 2
+	total := qrTotalCodewords(version)
+	numBlocks := qrNumBlocks[level][version]
+	numberEccSymbols := qrEccCodewordsPerBlock[level][version]
+	numShortBlocks := numBlocks - total%numBlocks
+	shortDataLength := total/numBlocks - numberEccSymbols
+
+	dataLengths := make([]int, numBlocks)
+	for b := range dataLengths {
+		dataLengths[b] = shortDataLength
+		if b >= numShortBlocks {
+			dataLengths[b]++
+		}
+	}
+
+	return &QRProfile{
+		Version: version,
+		Level:   level,
+		layout:  columnInterleavedLayout(dataLengths, numberEccSymbols),
+	}, nil
+}
+
+// TotalCodewords returns the number of codewords (data and ecc) in the symbol
+func (q *QRProfile) TotalCodewords() int {
+	return q.layout.length()
+}
+
+// DataCodewords returns the number of data codewords in the symbol
+func (q *QRProfile) DataCodewords() int {
+	return q.layout.dataLength()
+}
+
+// EccCodewordsPerBlock returns the number of ecc codewords of every block
+func (q *QRProfile) EccCodewordsPerBlock() int {
+	return q.layout.numberEccSymbols
+}
+
+// Blocks returns the number of data codewords of every block (group 1 first, then group 2)
+func (q *QRProfile) Blocks() []int {
+	return append([]int{}, q.layout.dataLengths...)
+}
+
+// Encode splits the data codewords into blocks, computes their ecc codewords and returns the interleaved codewords
+// in the order they are placed in the symbol.
+func (q *QRProfile) Encode(data []int) ([]int, error) {
+	if !isGaloisField(285, 0) {
+		return []int{}, errors.New("QR Codes require the galois field tables to be initialized with prim 285 and fcr 0")
+	}
+	return q.layout.encode(data)
+}
+
+// Decode de-interleaves the codewords read from the symbol, corrects every block and returns the data codewords.
+// erasedIndices are positions in the codeword stream (for example unreadable modules).
+// Blocks that can not be corrected are returned as received and the first failure is returned as the error.
+func (q *QRProfile) Decode(codewords []int, erasedIndices []int) ([]int, []BlockStats, error) {
+	if !isGaloisField(285, 0) {
+		return []int{}, []BlockStats{}, errors.New("QR Codes require the galois field tables to be initialized with prim 285 and fcr 0")
+	}
+	return q.layout.decode(codewords, erasedIndices)
+}
+
+// Number of codewords in a QR Code version: the modules left once the function patterns
+// (finder, alignment and timing patterns, format and version information) are removed, divided by 8.
+func qrTotalCodewords(version int) int {
+	modules := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		modules -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			modules -= 36
+		}
+	}
+	return modules / 8
+}
@@ -0,0 +1,141 @@
+package reedSolomon
+
+import (
+	"testing"
+)
+
+func TestQRProfileTables(t *testing.T) {
+	t.Log("Testing QR Code block tables")
+
+	tests := []struct {
+		version int
+		level   QRLevel
+		total   int
+		ecc     int
+		blocks  []int
+	}{
+		{1, QRLevelM, 26, 10, []int{16}},
+		{5, QRLevelQ, 134, 18, []int{15, 15, 16, 16}},
+		{7, QRLevelL, 196, 20, []int{78, 78}},
+		{40, QRLevelH, 3706, 30, nil}, // 20 blocks of 15 and 61 blocks of 16
+	}
+
+	for _, test := range tests {
+		q, err := NewQRProfile(test.version, test.level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q.TotalCodewords() != test.total {
+			t.Errorf("Version %d was expected to have %d codewords, but it had %d instead.", test.version, test.total, q.TotalCodewords())
+		}
+		if q.EccCodewordsPerBlock() != test.ecc {
+			t.Errorf("Version %d was expected to have %d ecc codewords per block, but it had %d instead.", test.version, test.ecc, q.EccCodewordsPerBlock())
+		}
+		for i, n := range test.blocks {
+			if q.Blocks()[i] != n {
+				t.Errorf("Block %d of version %d was expected to have %d data codewords, but it had %d instead.", i, test.version, n, q.Blocks()[i])
+			}
+		}
+	}
+
+	q, _ := NewQRProfile(40, QRLevelH)
+	blocks := q.Blocks()
+	if len(blocks) != 81 || blocks[19] != 15 || blocks[20] != 16 {
+		t.Errorf("Version 40-H was expected to have 20 blocks of 15 and 61 blocks of 16, but it had %d instead.", blocks)
+	}
+
+	if _, err := NewQRProfile(41, QRLevelL); err == nil {
+		t.Error("Should have stated that the version is invalid")
+	}
+}
+
+func TestQRProfileEncode(t *testing.T) {
+	t.Log("Testing QR Code encoding")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	// 1-M "01234567" from ISO/IEC 18004 Annex I
+	q, _ := NewQRProfile(1, QRLevelM)
+	data := []int{16, 32, 12, 86, 97, 128, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17}
+
+	expected := []int{16, 32, 12, 86, 97, 128, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17, 165, 36, 212, 193, 237, 54, 199, 135, 44, 85}
+	resp, err := q.Encode(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resp {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+}
+
+func TestQRProfileInterleaving(t *testing.T) {
+	t.Log("Testing QR Code block interleaving")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	// 5-Q: 2 blocks of 15 and 2 blocks of 16 data codewords
+	q, _ := NewQRProfile(5, QRLevelQ)
+	data := make([]int, q.DataCodewords())
+	for i := range data {
+		data[i] = i
+	}
+
+	resp, _ := q.Encode(data)
+
+	// The data codewords are interleaved column by column, the last column only has the 2 long blocks
+	expected := []int{0, 15, 30, 46, 1, 16, 31, 47}
+	for i, r := range expected {
+		if resp[i] != r {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, r, resp[i])
+		}
+	}
+	if resp[60] != 45 || resp[61] != 61 {
+		t.Errorf("The last data codewords were expected to be 45 and 61, but they were %d and %d instead.", resp[60], resp[61])
+	}
+}
+
+func TestQRProfileDecode(t *testing.T) {
+	t.Log("Testing QR Code decoding with damaged codewords")
+
+	InitGaloisFields(285, 0)
+	defer InitGaloisFields(301, 1)
+
+	q, _ := NewQRProfile(10, QRLevelH) // 4 blocks of 12 and 4 blocks of 13, 28 ecc codewords each
+	data := make([]int, q.DataCodewords())
+	for i := range data {
+		data[i] = (i * 11) % 256
+	}
+	codewords, _ := q.Encode(data)
+
+	// A damaged area of 60 consecutive codewords (spread over the 8 blocks) and 10 unreadable codewords
+	for p := 100; p < 160; p++ {
+		codewords[p] ^= 0x5A
+	}
+	erasures := []int{}
+	for p := 200; p < 210; p++ {
+		codewords[p] = 0
+		erasures = append(erasures, p)
+	}
+
+	decoded, stats, err := q.Decode(codewords, erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range decoded {
+		if r != data[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], r)
+		}
+	}
+	if len(stats) != 8 {
+		t.Errorf("Expected stats for 8 blocks, but there were %d instead.", len(stats))
+	}
+
+	if _, _, err := q.Decode(codewords[:10], []int{}); err == nil {
+		t.Error("Should have stated that the number of codewords is wrong")
+	}
+}
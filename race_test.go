@@ -0,0 +1,6 @@
+//go:build race
+
+package reedSolomon
+
+// The race detector drops some of the Decoders put back in the pools (see sync.Pool), so Decode allocates more
+const raceEnabled = true
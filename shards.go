@@ -0,0 +1,331 @@
+package reedSolomon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// Smallest number of bytes of every shard given to a goroutine, below it starting the goroutine costs more than it saves
+const defaultMinSplitSize = 16 * 1024
+
+// ShardEncoder splits data into dataShards equally sized data shards and computes parityShards parity shards.
+// Every byte offset across the shards forms one Reed-Solomon codeword (data shards first, parity shards last),
+// so any parityShards missing shards can be rebuilt from the ones that remain.
+// The galois field look up tables must be initialized (see InitGaloisFields) before use.
+type ShardEncoder struct {
+	dataShards   int
+	parityShards int
+	parityMatrix [][]*mulTable   // parityMatrix[j][i] multiplies data shard i into parity shard j
+	syndromes    *syndromeEngine // the syndromes of the byte offsets of the shards, computed a whole shard at a time
+	goroutines   int             // maximum number of goroutines sharing the byte ranges of the shards
+	minSplitSize int             // minimum number of bytes of every shard handled by one goroutine
+}
+
+// NewShardEncoder creates an encoder for dataShards data shards protected by parityShards parity shards.
+// The total number of shards can not be more than 255 (the length of a GF(256) codeword).
+func NewShardEncoder(dataShards, parityShards int) (*ShardEncoder, error) {
+	if dataShards <= 0 || parityShards < 0 {
+		return nil, errors.New("Invalid number of shards")
+	}
+	if dataShards+parityShards > 255 {
+		return nil, fmt.Errorf("Too many shards (%d when max is 255)", dataShards+parityShards)
+	}
+
+	return &ShardEncoder{
+		dataShards:   dataShards,
+		parityShards: parityShards,
+		parityMatrix: parityMatrix(dataShards, parityShards),
+		syndromes:    defaultField().newSyndromeEngine(parityShards),
+		goroutines:   runtime.GOMAXPROCS(0),
+		minSplitSize: defaultMinSplitSize,
+	}, nil
+}
+
+// SetConcurrency sets the number of goroutines Encode and Reconstruct split the shards across (by byte ranges),
+// and the minimum number of bytes of every shard a goroutine handles. The output does not depend on them.
+// goroutines <= 0 uses GOMAXPROCS (the default) and minSplitSize <= 0 uses the default of 16KiB.
+func (e *ShardEncoder) SetConcurrency(goroutines, minSplitSize int) {
+	if goroutines <= 0 {
+		goroutines = runtime.GOMAXPROCS(0)
+	}
+	if minSplitSize <= 0 {
+		minSplitSize = defaultMinSplitSize
+	}
+	e.goroutines = goroutines
+	e.minSplitSize = minSplitSize
+}
+
+// Split the byte offsets [0, size) of the shards in ranges of at least minSplitSize bytes and call work on each of
+// them from its own goroutine. The first error (in the order of the ranges) is returned.
+func (e *ShardEncoder) parallel(size int, work func(start, end int) error) error {
+	n := e.goroutines
+	if size/e.minSplitSize < n {
+		n = size / e.minSplitSize
+	}
+	if n <= 1 {
+		return work(0, size)
+	}
+
+	// Ranges are multiples of 64 bytes so that only the last one has a tail the SIMD kernels can't handle
+	split := ((size+n-1)/n + 63) &^ 63
+	errs := make([]error, (size+split-1)/split)
+
+	var wg sync.WaitGroup
+	for r := range errs {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			end := (r + 1) * split
+			if end > size {
+				end = size
+			}
+			errs[r] = work(r*split, end)
+		}(r)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The ecc symbols are a linear function of the message: the ecc of a message is the sum of the ecc of each of its
+// symbols alone. Column i of the matrix is the ecc of a message whose only non-zero symbol is a 1 at index i, so that
+// parity shard j is the sum of matrix[j][i] * data shard i (computed a whole shard at a time with the nibble tables).
+func parityMatrix(dataShards, parityShards int) [][]*mulTable {
+	gf := defaultField()
+	generator := gf.generatorPolynomial(parityShards)
+
+	matrix := make([][]*mulTable, parityShards)
+	for j := range matrix {
+		matrix[j] = make([]*mulTable, dataShards)
+	}
+
+	msg := make([]int, dataShards)
+	for i := range msg {
+		msg[i] = 1
+		for j, coef := range gf.calculateEcc(msg, generator) {
+			matrix[j][i] = gf.newMulTable(coef)
+		}
+		msg[i] = 0
+	}
+	return matrix
+}
+
+// Encode computes the parity shards from the data shards.
+// shards must hold dataShards+parityShards slices, the data shards first. All data shards must be the same size,
+// parity shards are (re)allocated when they are not big enough.
+func (e *ShardEncoder) Encode(shards [][]byte) error {
+	if len(shards) != e.dataShards+e.parityShards {
+		return fmt.Errorf("Wrong number of shards (%d when %d are expected)", len(shards), e.dataShards+e.parityShards)
+	}
+
+	size := len(shards[0])
+	for _, shard := range shards[:e.dataShards] {
+		if len(shard) != size {
+			return errors.New("Shard sizes do not match")
+		}
+	}
+	for i := e.dataShards; i < len(shards); i++ {
+		shards[i] = resizeShard(shards[i], size)
+	}
+
+	return e.parallel(size, func(start, end int) error {
+		for j, row := range e.parityMatrix {
+			parity := shards[e.dataShards+j][start:end]
+			mulSlice(row[0], shards[0][start:end], parity)
+			for i := 1; i < e.dataShards; i++ {
+				mulAddSlice(row[i], shards[i][start:end], parity)
+			}
+		}
+		return nil
+	})
+}
+
+// Split lays data out into dataShards equally sized data shards, padding the last one with 0's when the length of data
+// can not be divided evenly, and allocates the parity shards so that the result can be passed directly to Encode.
+// It also returns the original size of data, which Join needs to strip the padding.
+func (e *ShardEncoder) Split(data []byte) ([][]byte, int, error) {
+	if len(data) == 0 {
+		return nil, 0, errors.New("Not enough data to split")
+	}
+
+	shardSize := (len(data) + e.dataShards - 1) / e.dataShards
+
+	// One contiguous buffer is used for all the shards
+	buffer := make([]byte, shardSize*(e.dataShards+e.parityShards))
+	copy(buffer, data)
+
+	shards := make([][]byte, e.dataShards+e.parityShards)
+	e.splitBuffer(buffer, shardSize, shards)
+	return shards, len(data), nil
+}
+
+// Join writes the first size bytes of the data shards to w, stripping the padding added by Split.
+// Missing data shards must be rebuilt (see Reconstruct) before they can be joined.
+func (e *ShardEncoder) Join(w io.Writer, shards [][]byte, size int) error {
+	if len(shards) < e.dataShards {
+		return fmt.Errorf("Wrong number of shards (%d when %d are expected)", len(shards), e.dataShards)
+	}
+	if size < 0 {
+		return fmt.Errorf("Invalid size %d", size)
+	}
+
+	available := 0
+	for _, shard := range shards[:e.dataShards] {
+		if len(shard) == 0 {
+			return errors.New("Data shards are missing")
+		}
+		available += len(shard)
+	}
+	if available < size {
+		return fmt.Errorf("Not enough data in the shards (%d when %d are required)", available, size)
+	}
+
+	for _, shard := range shards[:e.dataShards] {
+		if len(shard) > size {
+			shard = shard[:size]
+		}
+		if _, err := w.Write(shard); err != nil {
+			return err
+		}
+
+		size -= len(shard)
+		if size == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Point shards at consecutive shardSize pieces of buffer, buffer must be big enough to hold all of them
+func (e *ShardEncoder) splitBuffer(buffer []byte, shardSize int, shards [][]byte) {
+	for i := range shards {
+		shards[i] = buffer[i*shardSize : (i+1)*shardSize : (i+1)*shardSize]
+	}
+}
+
+// Reconstruct rebuilds the missing shards (nil or empty slices) from the shards that are available.
+// At least dataShards shards must be present.
+func (e *ShardEncoder) Reconstruct(shards [][]byte) error {
+	return e.reconstruct(shards, false)
+}
+
+// ReconstructData only rebuilds the missing data shards, missing parity shards are left as they are.
+func (e *ShardEncoder) ReconstructData(shards [][]byte) error {
+	return e.reconstruct(shards, true)
+}
+
+func (e *ShardEncoder) reconstruct(shards [][]byte, dataOnly bool) error {
+	if len(shards) != e.dataShards+e.parityShards {
+		return fmt.Errorf("Wrong number of shards (%d when %d are expected)", len(shards), e.dataShards+e.parityShards)
+	}
+
+	// Find the missing shards, they will be passed to the decoder as erasures
+	size := -1
+	erasedIndices := []int{}
+	dataMissing := false
+
+	for i, shard := range shards {
+		if len(shard) == 0 {
+			erasedIndices = append(erasedIndices, i)
+			if i < e.dataShards {
+				dataMissing = true
+			}
+			continue
+		}
+		if size == -1 {
+			size = len(shard)
+		}
+		if len(shard) != size {
+			return errors.New("Shard sizes do not match")
+		}
+	}
+
+	if len(erasedIndices) > e.parityShards {
+		return fmt.Errorf("Too few shards to reconstruct (%d when %d are required)", len(shards)-len(erasedIndices), e.dataShards)
+	}
+	if len(erasedIndices) == 0 || (dataOnly && !dataMissing) {
+		return nil // nothing to do
+	}
+
+	for _, i := range erasedIndices {
+		if i < e.dataShards || !dataOnly {
+			shards[i] = resizeShard(shards[i], size)
+		}
+	}
+
+	erased := make([]bool, len(shards))
+	for _, i := range erasedIndices {
+		erased[i] = true
+	}
+
+	return e.parallel(size, func(start, end int) error {
+		// Every goroutine has its own decoder
+		decoder, err := NewDecoder(e.parityShards)
+		if err != nil {
+			return err
+		}
+
+		// The syndromes of all the byte offsets of the range, the erased shards count as 0's
+		columns := make([][]byte, len(shards))
+		for i, shard := range shards {
+			if !erased[i] {
+				columns[i] = shard[start:end]
+			}
+		}
+		synd := make([][]byte, e.parityShards)
+		for i := range synd {
+			synd[i] = make([]byte, end-start)
+		}
+		e.syndromes.bulkSyndromes(columns, synd)
+
+		msg := make([]int, len(shards))
+		for b := start; b < end; b++ {
+			clean := true
+			for i, s := range synd {
+				decoder.synd[i+1] = int(s[b-start])
+				if s[b-start] != 0 {
+					clean = false
+				}
+			}
+
+			// The erased symbols of a byte offset with clean syndromes are 0
+			for _, i := range erasedIndices {
+				msg[i] = 0
+			}
+			if !clean {
+				for i, shard := range shards {
+					if !erased[i] {
+						msg[i] = int(shard[b])
+					}
+				}
+				if err := decoder.correct(msg, erasedIndices); err != nil {
+					return err
+				}
+			}
+
+			for _, i := range erasedIndices {
+				if i < e.dataShards || !dataOnly {
+					shards[i][b] = byte(msg[i])
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Reuse the memory of shard if it is big enough, otherwise allocate a new shard
+func resizeShard(shard []byte, size int) []byte {
+	if cap(shard) >= size {
+		return shard[:size]
+	}
+	return make([]byte, size)
+}
@@ -0,0 +1,258 @@
+package reedSolomon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func makeTestShards(dataShards, parityShards, size int) [][]byte {
+	shards := make([][]byte, dataShards+parityShards)
+	for i := 0; i < dataShards; i++ {
+		shards[i] = make([]byte, size)
+		for j := range shards[i] {
+			shards[i][j] = byte(i*31 + j*7)
+		}
+	}
+	return shards
+}
+
+func TestNewShardEncoder(t *testing.T) {
+	t.Log("Testing creating shard encoders")
+
+	if _, err := NewShardEncoder(0, 2); err == nil {
+		t.Error("Should fail without data shards")
+	}
+	if _, err := NewShardEncoder(200, 56); err == nil || err.Error() != "Too many shards (256 when max is 255)" {
+		t.Error("Should have stated that there are too many shards")
+	}
+	if _, err := NewShardEncoder(10, 4); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestShardEncoderEncode(t *testing.T) {
+	t.Log("Testing encoding shards")
+
+	e, _ := NewShardEncoder(10, 8)
+	shards := makeTestShards(10, 8, 3)
+
+	if err := e.Encode(shards); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every column must be a valid codeword
+	for b := 0; b < 3; b++ {
+		msg := make([]int, len(shards))
+		for i := range shards {
+			msg[i] = int(shards[i][b])
+		}
+		if !isSyndromeClean(calculateSyndromes(msg, 8)) {
+			t.Errorf("Column %d is not a valid codeword", b)
+		}
+	}
+
+	// Mismatched shard sizes
+	shards[3] = shards[3][:2]
+	if err := e.Encode(shards); err == nil || err.Error() != "Shard sizes do not match" {
+		t.Error("Should have stated that the shard sizes do not match")
+	}
+}
+
+func TestShardEncoderReconstruct(t *testing.T) {
+	t.Log("Testing reconstructing missing shards")
+
+	e, _ := NewShardEncoder(5, 3)
+	shards := makeTestShards(5, 3, 50)
+	e.Encode(shards)
+
+	expected := make([][]byte, len(shards))
+	for i := range shards {
+		expected[i] = append([]byte{}, shards[i]...)
+	}
+
+	shards[0] = nil
+	shards[3] = nil
+	shards[6] = shards[6][:0]
+
+	if err := e.Reconstruct(shards); err != nil {
+		t.Fatal(err)
+	}
+	for i := range shards {
+		if !bytes.Equal(shards[i], expected[i]) {
+			t.Errorf("Shard %d was not reconstructed correctly", i)
+		}
+	}
+
+	// Data only
+	shards[1] = nil
+	shards[7] = nil
+	if err := e.ReconstructData(shards); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(shards[1], expected[1]) {
+		t.Error("Data shard 1 was not reconstructed correctly")
+	}
+	if len(shards[7]) != 0 {
+		t.Error("Parity shard 7 should not have been reconstructed")
+	}
+
+	// Too many missing shards
+	shards[0] = nil
+	shards[2] = nil
+	shards[4] = nil
+	if err := e.Reconstruct(shards); err == nil || err.Error() != "Too few shards to reconstruct (4 when 5 are required)" {
+		t.Error("Should have stated that there are too few shards")
+	}
+}
+
+func TestShardEncoderReconstructCleanColumns(t *testing.T) {
+	t.Log("Testing reconstructing shards whose byte offsets have clean syndromes")
+
+	e, _ := NewShardEncoder(10, 4)
+	e.SetConcurrency(4, 1000)
+	shards := makeTestShards(10, 4, 5000)
+	for b := 0; b < 5000; b += 3 {
+		for i := 0; i < 10; i++ {
+			shards[i][b] = 0 // a column of 0's is a codeword, its syndromes are clean once its symbols are erased
+		}
+	}
+	e.Encode(shards)
+
+	expected := make([][]byte, len(shards))
+	for i := range shards {
+		expected[i] = append([]byte{}, shards[i]...)
+	}
+
+	// The memory of the erased shards is reused, it must not be read
+	for _, i := range []int{1, 8, 12} {
+		for b := range shards[i] {
+			shards[i][b] = 0xFF
+		}
+		shards[i] = shards[i][:0]
+	}
+	if err := e.Reconstruct(shards); err != nil {
+		t.Fatal(err)
+	}
+	for i := range shards {
+		if !bytes.Equal(shards[i], expected[i]) {
+			t.Errorf("Shard %d was not reconstructed correctly", i)
+		}
+	}
+}
+
+func TestShardEncoderConcurrency(t *testing.T) {
+	t.Log("Testing that encoding and reconstructing with several goroutines gives the same shards")
+
+	single, _ := NewShardEncoder(10, 4)
+	single.SetConcurrency(1, 0)
+	shards := makeTestShards(10, 4, 10007) // the last range is shorter and has a tail
+	single.Encode(shards)
+
+	for _, goroutines := range []int{2, 3, 8, 0} {
+		e, _ := NewShardEncoder(10, 4)
+		e.SetConcurrency(goroutines, 1000)
+
+		resp := makeTestShards(10, 4, 10007)
+		if err := e.Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+		for i := range shards {
+			if !bytes.Equal(resp[i], shards[i]) {
+				t.Errorf("Shard %d encoded with %d goroutines does not match the single goroutine encoding", i, goroutines)
+			}
+		}
+
+		resp[2] = nil
+		resp[5] = nil
+		resp[11] = nil
+		resp[13] = nil
+		if err := e.Reconstruct(resp); err != nil {
+			t.Fatal(err)
+		}
+		for i := range shards {
+			if !bytes.Equal(resp[i], shards[i]) {
+				t.Errorf("Shard %d reconstructed with %d goroutines does not match the original", i, goroutines)
+			}
+		}
+	}
+
+	// Shards smaller than the minimum split size are done by the calling goroutine
+	e, _ := NewShardEncoder(10, 4)
+	e.SetConcurrency(8, 1<<20)
+	resp := makeTestShards(10, 4, 10007)
+	e.Encode(resp)
+	if !bytes.Equal(resp[12], shards[12]) {
+		t.Error("Parity shard 12 does not match the single goroutine encoding")
+	}
+}
+
+func TestShardEncoderSplitJoin(t *testing.T) {
+	t.Log("Testing splitting and joining shards")
+
+	e, _ := NewShardEncoder(4, 2)
+	data := []byte("hello world, this is 37 bytes of data")
+
+	shards, size, err := e.Split(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != len(data) {
+		t.Errorf("Split was expected to return the size %d, but it was %d instead.", len(data), size)
+	}
+	if len(shards) != 6 {
+		t.Fatalf("Expected 6 shards, but there were %d instead.", len(shards))
+	}
+	for i, shard := range shards {
+		if len(shard) != 10 {
+			t.Errorf("Shard %d was expected to be 10 bytes, but it was %d instead.", i, len(shard))
+		}
+	}
+	if !bytes.Equal(shards[3], []byte("of data\x00\x00\x00")) {
+		t.Errorf("Last data shard was not padded correctly: %q", shards[3])
+	}
+
+	if err := e.Encode(shards); err != nil {
+		t.Fatal(err)
+	}
+	shards[0] = nil
+	shards[3] = nil
+	if err := e.Reconstruct(shards); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	if err := e.Join(out, shards, size); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), data) {
+		t.Errorf("Joined data %q does not match the original data", out.Bytes())
+	}
+
+	// Asking for more data than the shards hold
+	if err := e.Join(out, shards, 41); err == nil || err.Error() != "Not enough data in the shards (40 when 41 are required)" {
+		t.Error("Should have stated that there is not enough data")
+	}
+
+	if err := e.Join(out, shards, -1); err == nil || err.Error() != "Invalid size -1" {
+		t.Error("Should have stated that the size is invalid")
+	}
+	if err := e.Join(out, shards[:3], size); err == nil || err.Error() != "Wrong number of shards (3 when 4 are expected)" {
+		t.Error("Should have stated that there are too few data shards")
+	}
+
+	// Missing data shard
+	shards[1] = nil
+	if err := e.Join(out, shards, len(data)); err == nil || err.Error() != "Data shards are missing" {
+		t.Error("Should have stated that data shards are missing")
+	}
+}
+
+func TestShardEncoderSplitEmpty(t *testing.T) {
+	t.Log("Testing splitting empty data")
+
+	e, _ := NewShardEncoder(4, 2)
+
+	if _, _, err := e.Split([]byte{}); err == nil {
+		t.Error("Should not be able to split empty data")
+	}
+}
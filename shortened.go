@@ -0,0 +1,111 @@
+package reedSolomon
+
+import (
+	"fmt"
+)
+
+// ShortenedCode is an RS(n, k) code shortened from the full length code of the field (255 symbols for GF(256)):
+// the first symbols of every codeword (the virtual fill) are always 0 and are never transmitted.
+// Encode and Decode pad the codewords with the virtual fill, and a decoding that would correct a symbol of the
+// virtual fill is rejected: the received word was closer to another codeword of the full length code,
+// so the correction can not be trusted.
+type ShortenedCode struct {
+	length           int          // n, number of transmitted symbols
+	numberEccSymbols int          // n - k
+	field            *galoisField // nil for the GF(256) field initialized by InitGaloisFields
+}
+
+// NewShortenedCode creates a code of length transmitted symbols, numberEccSymbols of them being ecc symbols,
+// over the GF(256) field initialized by InitGaloisFields.
+func NewShortenedCode(length, numberEccSymbols int) (*ShortenedCode, error) {
+	return newShortenedCode(nil, length, numberEccSymbols)
+}
+
+func newShortenedCode(field *galoisField, length, numberEccSymbols int) (*ShortenedCode, error) {
+	order := 255
+	if field != nil {
+		order = field.order
+	}
+	if length <= 0 || length > order {
+		return nil, fmt.Errorf("Invalid code length %d (must be between 1 and %d)", length, order)
+	}
+	if numberEccSymbols <= 0 || numberEccSymbols >= length {
+		return nil, fmt.Errorf("Invalid number of ecc symbols (%d when it must be between 1 and %d)", numberEccSymbols, length-1)
+	}
+
+	return &ShortenedCode{length: length, numberEccSymbols: numberEccSymbols, field: field}, nil
+}
+
+// Length returns the number of transmitted symbols of a codeword (n)
+func (s *ShortenedCode) Length() int {
+	return s.length
+}
+
+// DataLength returns the number of data symbols of a codeword (k)
+func (s *ShortenedCode) DataLength() int {
+	return s.length - s.numberEccSymbols
+}
+
+// VirtualFill returns the number of zero symbols that are prefixed to every codeword (and never transmitted)
+func (s *ShortenedCode) VirtualFill() int {
+	return s.galoisField().order - s.length
+}
+
+// Encode returns the transmitted codeword: the data symbols followed by their ecc symbols
+func (s *ShortenedCode) Encode(data []int) ([]int, error) {
+	if len(data) != s.DataLength() {
+		return []int{}, fmt.Errorf("Wrong number of data symbols (%d when %d are expected)", len(data), s.DataLength())
+	}
+
+	gf := s.galoisField()
+	codeword := gf.encodeMessage(s.pad(data), gf.generatorPolynomial(s.numberEccSymbols))
+	return codeword[s.VirtualFill():], nil
+}
+
+// Decode corrects a transmitted codeword and returns its data symbols. erasedIndices are positions in the transmitted codeword.
+// If the codeword can not be corrected, or if a correction lands in the virtual fill, the data symbols are returned
+// as received along with the error.
+func (s *ShortenedCode) Decode(codeword []int, erasedIndices []int) ([]int, BlockStats, error) {
+	if len(codeword) != s.length {
+		return []int{}, BlockStats{}, fmt.Errorf("Wrong number of symbols (%d when %d are expected)", len(codeword), s.length)
+	}
+
+	virtualFill := s.VirtualFill()
+	paddedErasures := make([]int, len(erasedIndices))
+	for i, p := range erasedIndices {
+		if p < 0 || p >= s.length {
+			return []int{}, BlockStats{}, fmt.Errorf("Erasure position %d is out of range", p)
+		}
+		paddedErasures[i] = virtualFill + p
+	}
+
+	padded := s.pad(codeword)
+	correctedMsg, stats := s.galoisField().decodeBlock(padded, s.numberEccSymbols, paddedErasures)
+
+	if stats.Err == nil {
+		for p := 0; p < virtualFill; p++ {
+			if correctedMsg[p] != 0 {
+				stats.Err = fmt.Errorf("Could not correct message: correction found in the virtual fill (position %d)", p-virtualFill)
+				stats.Corrected = 0
+				correctedMsg = padded[:len(correctedMsg)] // keep the data symbols as received
+				break
+			}
+		}
+	}
+
+	return correctedMsg[virtualFill:], stats, stats.Err
+}
+
+// Prefix the symbols with the virtual fill
+func (s *ShortenedCode) pad(symbols []int) []int {
+	padded := make([]int, s.VirtualFill()+len(symbols))
+	copy(padded[s.VirtualFill():], symbols)
+	return padded
+}
+
+func (s *ShortenedCode) galoisField() *galoisField {
+	if s.field == nil {
+		return defaultField()
+	}
+	return s.field
+}
@@ -0,0 +1,86 @@
+package reedSolomon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShortenedCode(t *testing.T) {
+	t.Log("Testing a shortened RS(40,32) code")
+
+	s, err := NewShortenedCode(40, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.VirtualFill() != 215 || s.DataLength() != 32 {
+		t.Errorf("A virtual fill of 215 and 32 data symbols were expected, but they were %d and %d instead.", s.VirtualFill(), s.DataLength())
+	}
+
+	data := make([]int, 32)
+	for i := range data {
+		data[i] = i*7 + 1
+	}
+	codeword, err := s.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The virtual fill doesn't change the codeword, the leading 0's of a polynomial are implicit
+	expected, _ := Encode(data, 8)
+	for i, r := range codeword {
+		if r != expected[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, expected[i], r)
+		}
+	}
+
+	// 2 errors and 4 erasures
+	codeword[3] ^= 0x11
+	codeword[38] ^= 0x22
+	erasures := []int{0, 10, 20, 30}
+	for _, p := range erasures {
+		codeword[p] = 0
+	}
+
+	resp, stats, err := s.Decode(codeword, erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Erasures != 4 {
+		t.Errorf("4 erasures were expected, but there were %d instead.", stats.Erasures)
+	}
+	for i, r := range resp {
+		if r != data[i] {
+			t.Errorf("Response at index %d was expected to be %d, but it was %d instead.", i, data[i], r)
+		}
+	}
+}
+
+func TestShortenedCodeVirtualFillCorrection(t *testing.T) {
+	t.Log("Testing that corrections in the virtual fill are rejected")
+
+	s, _ := NewShortenedCode(40, 8)
+
+	// A codeword of the full length code whose only non-zero data symbol is in the virtual fill: once shortened,
+	// it is one symbol away from a codeword of the full length code and the decoder would correct the virtual fill.
+	full := make([]int, 255-8)
+	full[100] = 0x42
+	codeword, _ := Encode(full, 8)
+	received := codeword[s.VirtualFill():]
+
+	resp, stats, err := s.Decode(received, []int{})
+	if err == nil || !strings.Contains(err.Error(), "virtual fill") {
+		t.Fatalf("Should have stated that a correction was found in the virtual fill, but the error was %v instead.", err)
+	}
+	if stats.Err == nil || stats.Corrected != 0 {
+		t.Error("The statistics were expected to report the failure without corrections")
+	}
+	for i, r := range resp {
+		if r != received[i] {
+			t.Errorf("Response at index %d was expected to be %d as received, but it was %d instead.", i, received[i], r)
+		}
+	}
+
+	if _, err := NewShortenedCode(256, 8); err == nil {
+		t.Error("Should have stated that the code is too long")
+	}
+}
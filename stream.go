@@ -0,0 +1,166 @@
+package reedSolomon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamEncoder encodes and reconstructs data streams that are too large to hold in memory.
+// The input is read blockSize*dataShards bytes at a time, each block is split into dataShards shards
+// and protected with parityShards parity shards (see ShardEncoder). Every shard is written to its own stream.
+type StreamEncoder struct {
+	shardEncoder *ShardEncoder
+	blockSize    int // maximum number of bytes written to each shard stream per block
+}
+
+// NewStreamEncoder creates a stream encoder for dataShards data streams protected by parityShards parity streams,
+// processing blockSize bytes of every shard stream at a time.
+func NewStreamEncoder(dataShards, parityShards, blockSize int) (*StreamEncoder, error) {
+	if blockSize <= 0 {
+		return nil, errors.New("Invalid block size")
+	}
+
+	shardEncoder, err := NewShardEncoder(dataShards, parityShards)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamEncoder{shardEncoder: shardEncoder, blockSize: blockSize}, nil
+}
+
+// SetConcurrency sets the number of goroutines every block is encoded and reconstructed with (see ShardEncoder.SetConcurrency)
+func (s *StreamEncoder) SetConcurrency(goroutines, minSplitSize int) {
+	s.shardEncoder.SetConcurrency(goroutines, minSplitSize)
+}
+
+// Encode reads data until EOF and writes dataShards+parityShards shard streams to shards.
+// The last block is padded with 0's so that it can be split evenly, the size of data is therefore needed to
+// reconstruct it exactly.
+func (s *StreamEncoder) Encode(data io.Reader, shards []io.Writer) error {
+	_, err := s.EncodeContext(context.Background(), data, shards)
+	return err
+}
+
+// EncodeContext is Encode stopping between blocks when ctx is cancelled (returning ctx.Err()).
+// It returns the number of bytes of data whose shards have been written, the shard streams hold complete blocks.
+func (s *StreamEncoder) EncodeContext(ctx context.Context, data io.Reader, shards []io.Writer) (int64, error) {
+	e := s.shardEncoder
+	if len(shards) != e.dataShards+e.parityShards {
+		return 0, fmt.Errorf("Wrong number of shards (%d when %d are expected)", len(shards), e.dataShards+e.parityShards)
+	}
+
+	buffer := make([]byte, s.blockSize*len(shards))
+	blockShards := make([][]byte, len(shards))
+	var encoded int64
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return encoded, err
+		}
+
+		n, err := io.ReadFull(data, buffer[:s.blockSize*e.dataShards])
+		if err == io.EOF {
+			return encoded, nil // all data has been encoded
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return encoded, err
+		}
+
+		// Split the block in equal shards, padding the end of a short (last) block with 0's
+		shardSize := (n + e.dataShards - 1) / e.dataShards
+		for i := n; i < shardSize*e.dataShards; i++ {
+			buffer[i] = 0
+		}
+		e.splitBuffer(buffer, shardSize, blockShards)
+
+		if err := e.Encode(blockShards); err != nil {
+			return encoded, err
+		}
+
+		for i, w := range shards {
+			if _, err := w.Write(blockShards[i]); err != nil {
+				return encoded, fmt.Errorf("Could not write shard %d: %s", i, err)
+			}
+		}
+		encoded += int64(n)
+	}
+}
+
+// Reconstruct reads the shard streams written by Encode and writes the original size bytes of data.
+// Missing shard streams must be nil, at least dataShards of them have to be available.
+func (s *StreamEncoder) Reconstruct(shards []io.Reader, data io.Writer, size int64) error {
+	_, err := s.ReconstructContext(context.Background(), shards, data, size)
+	return err
+}
+
+// ReconstructContext is Reconstruct stopping between blocks when ctx is cancelled (returning ctx.Err()).
+// It returns the number of bytes written to data.
+func (s *StreamEncoder) ReconstructContext(ctx context.Context, shards []io.Reader, data io.Writer, size int64) (int64, error) {
+	e := s.shardEncoder
+	if len(shards) != e.dataShards+e.parityShards {
+		return 0, fmt.Errorf("Wrong number of shards (%d when %d are expected)", len(shards), e.dataShards+e.parityShards)
+	}
+
+	available := 0
+	buffers := make([][]byte, len(shards))
+	for i, r := range shards {
+		if r != nil {
+			available++
+			buffers[i] = make([]byte, s.blockSize)
+		}
+	}
+	if available < e.dataShards {
+		return 0, fmt.Errorf("Too few shards to reconstruct (%d when %d are required)", available, e.dataShards)
+	}
+
+	blockShards := make([][]byte, len(shards))
+	var written int64
+
+	for written < size {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		// Read the next block of every available shard, they all have to be the same size
+		shardSize := -1
+		for i, r := range shards {
+			if r == nil {
+				blockShards[i] = blockShards[i][:0] // missing, keep the memory for the next block
+				continue
+			}
+
+			n, err := io.ReadFull(r, buffers[i])
+			if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+				return written, fmt.Errorf("Could not read shard %d: %s", i, err)
+			}
+			if shardSize == -1 {
+				shardSize = n
+			}
+			if n != shardSize {
+				return written, errors.New("Shard sizes do not match")
+			}
+			blockShards[i] = buffers[i][:n]
+		}
+
+		if shardSize == 0 {
+			return written, io.ErrUnexpectedEOF // the shards ended before all data could be reconstructed
+		}
+
+		if err := e.ReconstructData(blockShards); err != nil {
+			return written, err
+		}
+
+		blockSize := int64(shardSize * e.dataShards)
+		if blockSize > size-written {
+			blockSize = size - written // strip the padding of the last block
+		}
+		if err := e.Join(data, blockShards, int(blockSize)); err != nil {
+			return written, err
+		}
+		written += blockSize
+	}
+
+	return written, nil
+}
@@ -0,0 +1,148 @@
+package reedSolomon
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+)
+
+func encodeTestStream(t *testing.T, s *StreamEncoder, data []byte, numShards int) []*bytes.Buffer {
+	buffers := make([]*bytes.Buffer, numShards)
+	writers := make([]io.Writer, numShards)
+	for i := range buffers {
+		buffers[i] = &bytes.Buffer{}
+		writers[i] = buffers[i]
+	}
+
+	if err := s.Encode(bytes.NewReader(data), writers); err != nil {
+		t.Fatal(err)
+	}
+	return buffers
+}
+
+func TestStreamEncoderRoundTrip(t *testing.T) {
+	t.Log("Testing encoding and reconstructing a stream")
+
+	data := make([]byte, 1000) // not a multiple of the block size
+	for i := range data {
+		data[i] = byte(i * 13)
+	}
+
+	s, err := NewStreamEncoder(4, 2, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffers := encodeTestStream(t, s, data, 6)
+
+	// 1000 bytes = 3 full blocks of 4*64 bytes + a last block of 232 bytes (4 shards of 58 bytes)
+	for i, b := range buffers {
+		if b.Len() != 3*64+58 {
+			t.Errorf("Shard %d was expected to be %d bytes, but it was %d instead.", i, 3*64+58, b.Len())
+		}
+	}
+
+	readers := make([]io.Reader, 6)
+	for i, b := range buffers {
+		readers[i] = bytes.NewReader(b.Bytes())
+	}
+	readers[1] = nil // missing data shard
+	readers[4] = nil // missing parity shard
+
+	out := &bytes.Buffer{}
+	if err := s.Reconstruct(readers, out, int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), data) {
+		t.Error("Reconstructed stream does not match the original data")
+	}
+}
+
+func TestStreamEncoderTooFewShards(t *testing.T) {
+	t.Log("Testing reconstructing a stream with too few shards")
+
+	s, _ := NewStreamEncoder(4, 2, 64)
+	buffers := encodeTestStream(t, s, []byte("hello world"), 6)
+
+	readers := []io.Reader{nil, nil, nil, bytes.NewReader(buffers[3].Bytes()), bytes.NewReader(buffers[4].Bytes()), bytes.NewReader(buffers[5].Bytes())}
+
+	err := s.Reconstruct(readers, &bytes.Buffer{}, 11)
+	if err == nil || err.Error() != "Too few shards to reconstruct (3 when 4 are required)" {
+		t.Error("Should have stated that there are too few shards")
+	}
+}
+
+func TestStreamEncoderTruncated(t *testing.T) {
+	t.Log("Testing reconstructing a stream from truncated shards")
+
+	s, _ := NewStreamEncoder(2, 1, 16)
+	buffers := encodeTestStream(t, s, []byte("hello world"), 3)
+
+	readers := make([]io.Reader, 3)
+	for i, b := range buffers {
+		readers[i] = bytes.NewReader(b.Bytes())
+	}
+
+	err := s.Reconstruct(readers, &bytes.Buffer{}, 100) // more data than was encoded
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected %s, but got %v instead.", io.ErrUnexpectedEOF, err)
+	}
+}
+
+// cancelWriter cancels its context on the first write
+type cancelWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	w.cancel()
+	return w.Buffer.Write(p)
+}
+
+func TestStreamEncoderCancelled(t *testing.T) {
+	t.Log("Testing cancelling the encoding and reconstruction of a stream")
+
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i * 13)
+	}
+	s, _ := NewStreamEncoder(4, 2, 64)
+
+	// The block being written is finished, the next ones are not encoded
+	ctx, cancel := context.WithCancel(context.Background())
+	writers := []io.Writer{&cancelWriter{cancel: cancel}, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}}
+	n, err := s.EncodeContext(ctx, bytes.NewReader(data), writers)
+	if err != context.Canceled || n != 4*64 {
+		t.Errorf("Encoding was expected to stop after %d bytes with %v, but it stopped after %d bytes with %v instead.", 4*64, context.Canceled, n, err)
+	}
+	if l := writers[5].(*bytes.Buffer).Len(); l != 64 {
+		t.Errorf("Parity shard 5 was expected to hold a block of 64 bytes, but it held %d instead.", l)
+	}
+
+	buffers := encodeTestStream(t, s, data, 6)
+	readers := make([]io.Reader, 6)
+	for i, b := range buffers {
+		readers[i] = bytes.NewReader(b.Bytes())
+	}
+	readers[0] = nil
+
+	ctx, cancel = context.WithCancel(context.Background())
+	out := &cancelWriter{cancel: cancel}
+	n, err = s.ReconstructContext(ctx, readers, out, int64(len(data)))
+	if err != context.Canceled || n != 4*64 {
+		t.Errorf("Reconstruction was expected to stop after %d bytes with %v, but it stopped after %d bytes with %v instead.", 4*64, context.Canceled, n, err)
+	}
+	if !bytes.Equal(out.Bytes(), data[:n]) {
+		t.Error("The reconstructed part of the stream does not match the original data")
+	}
+
+	// Not cancelled: the whole stream
+	for i, b := range buffers {
+		readers[i] = bytes.NewReader(b.Bytes())
+	}
+	n, err = s.ReconstructContext(context.Background(), readers, &bytes.Buffer{}, int64(len(data)))
+	if err != nil || n != int64(len(data)) {
+		t.Errorf("Reconstruction was expected to write %d bytes, but it wrote %d bytes (%v) instead.", len(data), n, err)
+	}
+}
@@ -0,0 +1,77 @@
+package reedSolomon
+
+// Compute the syndromes of msg into synd in a single pass over its symbols: Horner's scheme is run for every root of
+// the generator polynomial at once (synd[i] = synd[i] * alpha^(i-1+fcr) + symbol), instead of one polynomial
+// evaluation per syndrome. The roots are kept in the log domain so nothing is recomputed between symbols.
+// synd holds nsym+1 coefficients, the first one being 0 (see calculateSyndromes). It reports if all the syndromes are 0.
+func (gf *galoisField) syndromes(msg, synd []int) bool {
+	synd[0] = 0
+	synd = synd[1:]
+	for i := range synd {
+		synd[i] = 0
+	}
+
+	firstRoot := gf.fcr % gf.order
+	if firstRoot < 0 {
+		firstRoot += gf.order
+	}
+
+	for _, c := range msg {
+		root := firstRoot // log(alpha^(i+fcr))
+		for i, x := range synd {
+			if x != 0 {
+				x = gf.exponents[gf.logs[x]+root]
+			}
+			synd[i] = x ^ c
+
+			root++
+			if root == gf.order {
+				root = 0
+			}
+		}
+	}
+
+	for _, x := range synd {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// syndromeEngine computes the syndromes of many interleaved codewords at once, like the columns of the shards of
+// ShardEncoder. The roots of the generator polynomial are kept as nibble tables so that the syndromes are updated a
+// whole shard at a time with the slice multiplications. Only the fields of at most 256 elements have them.
+type syndromeEngine struct {
+	tables []mulTable // tables[i] multiplies by alpha^(i+fcr)
+	one    *mulTable  // the nibble tables of 1, to add the symbols to the syndromes
+}
+
+// Build the nibble tables of the roots of the syndromes of codewords with nsym ecc symbols
+func (gf *galoisField) newSyndromeEngine(nsym int) *syndromeEngine {
+	s := &syndromeEngine{tables: make([]mulTable, nsym), one: gf.newMulTable(1)}
+	for i := range s.tables {
+		gf.fillMulTable(&s.tables[i], gf.alphaPower(i+gf.fcr))
+	}
+	return s
+}
+
+// Compute the syndromes of interleaved codewords: the ith symbol of codeword b is shards[i][b]. An empty shard stands
+// for a shard of 0's (the erased shards of Reconstruct). synd[i][b] receives syndrome i (without the prepended 0) of
+// codeword b, every synd[i] must be as long as the shards.
+func (s *syndromeEngine) bulkSyndromes(shards [][]byte, synd [][]byte) {
+	for i := range synd {
+		for b := range synd[i] {
+			synd[i][b] = 0
+		}
+	}
+
+	for _, shard := range shards {
+		for i := range synd {
+			mulSlice(&s.tables[i], synd[i], synd[i])
+			if len(shard) > 0 {
+				mulAddSlice(s.one, shard, synd[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package reedSolomon
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// One polynomial evaluation per syndrome, the way the syndromes were computed before the syndrome engine
+func referenceSyndromes(gf *galoisField, msg []int, nsym int) []int {
+	synd := make([]int, nsym+1)
+	for i := 0; i < nsym; i++ {
+		synd[i+1] = gf.polynomialEval(msg, gf.alphaPower(i+gf.fcr))
+	}
+	return synd
+}
+
+func TestSyndromes(t *testing.T) {
+	t.Log("Testing the single pass syndromes against one evaluation per syndrome")
+
+	fields := []*galoisField{defaultField(), newGaloisField(8, 0x11D, 0), ccsdsField, aztecModeField, aztecField6, aztecField12}
+	r := rand.New(rand.NewSource(3))
+
+	for _, gf := range fields {
+		for _, nsym := range []int{1, 4, 10} {
+			msg := make([]int, 100)
+			for i := range msg {
+				msg[i] = r.Intn(gf.order + 1)
+			}
+			if len(msg) > gf.order {
+				msg = msg[:gf.order]
+			}
+			expected := referenceSyndromes(gf, msg, nsym)
+
+			resp := make([]int, nsym+1)
+			gf.syndromes(msg, resp)
+			for i, v := range resp {
+				if v != expected[i] {
+					t.Fatalf("Syndrome %d of GF(%d) was expected to be %d, but it was %d instead.", i, gf.order+1, expected[i], v)
+				}
+			}
+		}
+	}
+
+	// A codeword has clean syndromes
+	codeword, _ := Encode([]int{1, 2, 3, 4, 5}, 6)
+	if !defaultField().syndromes(codeword, make([]int, 7)) {
+		t.Error("The syndromes of a codeword should all be 0")
+	}
+}
+
+func TestBulkSyndromes(t *testing.T) {
+	t.Log("Testing the syndromes of interleaved codewords")
+
+	gf := defaultField()
+	engine := gf.newSyndromeEngine(6)
+
+	shards := make([][]byte, 20)
+	for i := range shards {
+		shards[i] = make([]byte, 77)
+		for b := range shards[i] {
+			shards[i][b] = byte(i*29 + b*b)
+		}
+	}
+	shards[7] = nil // an erased shard counts as 0's
+	synd := make([][]byte, 6)
+	for i := range synd {
+		synd[i] = make([]byte, 77)
+	}
+	engine.bulkSyndromes(shards, synd)
+
+	column := make([]int, len(shards))
+	for b := 0; b < 77; b++ {
+		for i, shard := range shards {
+			column[i] = 0
+			if len(shard) > 0 {
+				column[i] = int(shard[b])
+			}
+		}
+		expected := referenceSyndromes(gf, column, 6)
+		for i := range synd {
+			if int(synd[i][b]) != expected[i+1] {
+				t.Fatalf("Syndrome %d of column %d was expected to be %d, but it was %d instead.", i, b, expected[i+1], synd[i][b])
+			}
+		}
+	}
+}
+
+func benchmarkSyndromes(b *testing.B, syndromes func(msg []int)) {
+	msg := make([]int, 255)
+	for i := range msg {
+		msg[i] = i * 37 & 0xFF
+	}
+	for i := 0; i < b.N; i++ {
+		syndromes(msg)
+	}
+}
+
+// The 32 syndromes of a RS(255, 223) codeword
+func BenchmarkSyndromesPerEvaluation(b *testing.B) {
+	gf := defaultField()
+	benchmarkSyndromes(b, func(msg []int) { referenceSyndromes(gf, msg, 32) })
+}
+
+func BenchmarkSyndromesSinglePass(b *testing.B) {
+	gf := defaultField()
+	benchmarkSyndromes(b, func(msg []int) { gf.calculateSyndromes(msg, 32) })
+}
+
+// The 32 syndromes of 4096 interleaved RS(255, 223) codewords
+func BenchmarkBulkSyndromes(b *testing.B) {
+	engine := defaultField().newSyndromeEngine(32)
+	shards := makeTestShards(255, 0, 4096)
+	synd := make([][]byte, 32)
+	for i := range synd {
+		synd[i] = make([]byte, 4096)
+	}
+	b.SetBytes(255 * 4096)
+	for i := 0; i < b.N; i++ {
+		engine.bulkSyndromes(shards, synd)
+	}
+}